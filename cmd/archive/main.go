@@ -0,0 +1,113 @@
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+)
+
+const usage = `Usage: %s <command> [flags]
+
+Commands:
+  export [-user-data] [-o file]    write an archive to file, or stdout; files ending in .gz are compressed
+  import [-user-data=false] file   load an archive from file, or stdin with "-"
+`
+
+func main() {
+	godotenv.Load()
+	config.Init()
+
+	if len(os.Args) < 2 {
+		exitUsage()
+	}
+	switch os.Args[1] {
+	case "export":
+		runExport(os.Args[2:])
+	case "import":
+		runImport(os.Args[2:])
+	default:
+		exitUsage()
+	}
+}
+
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	userData := fs.Bool("user-data", false, "include favorites and progress")
+	output := fs.String("o", "-", `output file, "-" for stdout`)
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		exitUsage()
+	}
+
+	var w io.Writer = os.Stdout
+	// Closers run in order once the archive is written; gzip must flush
+	// before the file is closed.
+	var closers []io.Closer
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			logrus.Fatalf("Failed to create %s: %s", *output, err.Error())
+		}
+		w = f
+		closers = append(closers, f)
+		if strings.HasSuffix(*output, ".gz") {
+			gz := gzip.NewWriter(f)
+			w = gz
+			closers = append([]io.Closer{gz}, closers...)
+		}
+	}
+
+	database.Init()
+	counts, err := database.ExportArchive(database.DB, w, archive.Options{UserData: *userData})
+	if err != nil {
+		logrus.Fatalf("Export failed: %s", err.Error())
+	}
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			logrus.Fatalf("Failed to write %s: %s", *output, err.Error())
+		}
+	}
+	logrus.Infof("Exported %d tags, %d quickstarts, %d help topics, %d aliases, %d favorites and %d progress records",
+		counts.Tags, counts.Quickstarts, counts.HelpTopics, counts.Aliases, counts.Favorites, counts.Progress)
+}
+
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	userData := fs.Bool("user-data", false, "import the favorites and progress contained in the archive")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		exitUsage()
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			logrus.Fatalf("Failed to open %s: %s", path, err.Error())
+		}
+		defer f.Close()
+		r = f
+	}
+
+	database.Init()
+	counts, err := database.ImportArchive(database.DB, r, archive.Options{UserData: *userData})
+	if err != nil {
+		logrus.Fatalf("Import failed: %s", err.Error())
+	}
+	logrus.Infof("Imported %d tags, %d quickstarts, %d help topics, %d aliases, %d favorites and %d progress records (%d skipped)",
+		counts.Tags, counts.Quickstarts, counts.HelpTopics, counts.Aliases, counts.Favorites, counts.Progress, counts.Skipped)
+}
+
+func exitUsage() {
+	fmt.Fprintf(os.Stderr, usage, os.Args[0])
+	os.Exit(2)
+}
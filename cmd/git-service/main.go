@@ -14,6 +14,7 @@ import (
 	ghclient "github.com/RedHatInsights/quickstarts/pkg/git-service/github"
 	githandlers "github.com/RedHatInsights/quickstarts/pkg/git-service/handlers"
 	pskmw "github.com/RedHatInsights/quickstarts/pkg/git-service/middleware"
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
@@ -32,8 +33,16 @@ func main() {
 		logrus.Warn("GIT_SERVICE_ENABLED is not set; assuming local development mode")
 	}
 
+	shutdownTracing, err := tracing.Init(context.Background(), "quickstarts-git-service", cfg.TracesExporter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to initialize tracing, spans will not be exported")
+		shutdownTracing = func(context.Context) error { return nil }
+	}
+	logrus.AddHook(tracing.LogrusHook{})
+
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
+	r.Use(tracing.Middleware)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
 
@@ -100,4 +109,7 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		logrus.WithError(err).Error("Server shutdown error")
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to flush traces")
+	}
 }
@@ -1,23 +1,100 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/database"
-	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 )
 
+const usage = `Usage: %s [command]
+
+Commands:
+  seed       apply pending migrations, then seed content (default)
+  up         apply pending migrations
+  down [n]   revert the last n migrations (default 1)
+  status     list migrations and whether they are applied
+`
+
 func main() {
 	godotenv.Load()
 	config.Init()
+
+	command := "seed"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	steps := 1
+	switch command {
+	case "seed", "up", "status":
+		if len(os.Args) > 2 {
+			exitUsage()
+		}
+	case "down":
+		if len(os.Args) > 3 {
+			exitUsage()
+		}
+		if len(os.Args) == 3 {
+			n, err := strconv.Atoi(os.Args[2])
+			if err != nil || n <= 0 {
+				exitUsage()
+			}
+			steps = n
+		}
+	default:
+		exitUsage()
+	}
+
 	database.Init()
-	err := database.DB.AutoMigrate(&models.Quickstart{}, &models.QuickstartProgress{}, &models.Tag{}, &models.HelpTopic{}, &models.FavoriteQuickstart{})
+	migrations, err := database.Migrations()
 	if err != nil {
-		panic(err)
+		logrus.Fatalf("Failed to load migrations: %s", err.Error())
 	}
 
-	logrus.Info("Migration complete")
-	database.SeedTags()
-	logrus.Info("Seeding complete")
+	switch command {
+	case "seed", "up":
+		applied, err := database.MigrateUp(database.DB, migrations)
+		if err != nil {
+			logrus.Fatalf("Migration failed: %s", err.Error())
+		}
+		logrus.Infof("Migration complete, %d applied", applied)
+		if command == "seed" {
+			// Only content errors, which SEED_STRICT or SEED_ERROR_BUDGET
+			// opt into, fail the job. Other seeding failures are logged and
+			// the previous content keeps being served.
+			var contentErr *database.SeedContentError
+			if err := database.SeedTags(); errors.As(err, &contentErr) {
+				logrus.Fatalf("Seeding aborted: %s", err.Error())
+			}
+			logrus.Info("Seeding complete")
+		}
+	case "down":
+		reverted, err := database.MigrateDown(database.DB, migrations, steps)
+		if err != nil {
+			logrus.Fatalf("Rollback failed: %s", err.Error())
+		}
+		logrus.Infof("Rollback complete, %d reverted", reverted)
+	case "status":
+		statuses, err := database.MigrationStatuses(database.DB, migrations)
+		if err != nil {
+			logrus.Fatalf("Failed to read migration status: %s", err.Error())
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+			}
+			fmt.Printf("%04d  %-30s  %s\n", s.Version, s.Name, applied)
+		}
+	}
+}
+
+func exitUsage() {
+	fmt.Fprintf(os.Stderr, usage, os.Args[0])
+	os.Exit(2)
 }
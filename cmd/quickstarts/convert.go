@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+)
+
+// runConvert converts the YAML file at input to indented JSON and writes it
+// to output, or to stdout for "-".
+func runConvert(input, output string) error {
+	yamlData, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("reading YAML file: %w", err)
+	}
+	jsonData, err := convertYAML(yamlData)
+	if err != nil {
+		return fmt.Errorf("converting %s: %w", input, err)
+	}
+	if output == "-" {
+		_, err = os.Stdout.Write(jsonData)
+		return err
+	}
+	if err := os.WriteFile(output, jsonData, 0644); err != nil {
+		return fmt.Errorf("writing JSON file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Successfully converted %s to %s\n", input, output)
+	return nil
+}
+
+func convertYAML(yamlData []byte) ([]byte, error) {
+	jsonData, err := yaml.YAMLToJSON(yamlData)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(jsonData, &value); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
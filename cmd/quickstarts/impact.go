@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/impact"
+)
+
+// runImpact compares two versions of the content, each a content directory
+// or a git ref, and writes what the change does to the catalog as Markdown.
+func runImpact(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("impact", flag.ContinueOnError)
+	repo := flags.String("repo", ".", "git repository to check refs out of")
+	path := flags.String("path", "docs", "content directory inside the repository; also the head when only the base is given")
+	countUsers := flags.Bool("db", false, "count the orphaned favorites and progress in the database configured by the environment")
+	output := flags.String("o", "-", `output file, "-" for stdout`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		return fmt.Errorf("impact takes a base and optionally a head, each a content directory or git ref")
+	}
+	head := *path
+	if flags.NArg() == 2 {
+		head = flags.Arg(1)
+	}
+
+	base, baseLabel, err := openCatalog(flags.Arg(0), *repo, *path)
+	if err != nil {
+		return err
+	}
+	headCatalog, headLabel, err := openCatalog(head, *repo, *path)
+	if err != nil {
+		return err
+	}
+	report := impact.Compare(base, headCatalog)
+	report.Base, report.Head = baseLabel, headLabel
+
+	if *countUsers {
+		config.Init()
+		database.Init()
+		if err := report.CountUsers(database.DB); err != nil {
+			return fmt.Errorf("count users: %w", err)
+		}
+	}
+
+	if *output == "-" {
+		return report.WriteMarkdown(w)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := report.WriteMarkdown(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// openCatalog loads version, a content directory or else a ref of repo, and
+// describes where it came from.
+func openCatalog(version, repo, path string) (*impact.Catalog, string, error) {
+	if info, err := os.Stat(version); err == nil && info.IsDir() {
+		catalog, err := impact.Load(version)
+		return catalog, version, err
+	}
+	src, err := database.OpenGitContent(repo, version, "", path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer src.Close()
+	catalog, err := impact.Load(src.Dir)
+	return catalog, fmt.Sprintf("%s (%.12s)", version, src.Revision), err
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+)
+
+const usage = `Usage: %s <command> [arguments]
+
+Commands:
+  new [quickstart|helptopic|learning-path] [flags]   scaffold a content item; prompts for values not given as flags
+  validate [flags]                                   validate the content under docs/; see validate -h
+  preview <dir|metadata.yml>                         print a content item as the API serves it
+  preview [-addr addr] [-dir docs]                   serve the API from the content directory and reload on changes
+  impact [flags] <base> [head]                       compare two content directories or git refs as Markdown; see impact -h
+  tags list [kind]                                   list tag kinds and their known values
+  convert <input.yaml> [output.json]                 convert YAML to indented JSON, on stdout without output
+
+Run from the repository root.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		exitUsage()
+	}
+	args := os.Args[2:]
+	var err error
+	switch os.Args[1] {
+	case "new":
+		err = runNew(args, os.Stdin, os.Stdout)
+	case "validate":
+		os.Exit(validate.Command(os.Args[0]+" validate", args, os.Stdout, os.Stderr))
+	case "preview":
+		err = runPreview(args, os.Stdout)
+	case "impact":
+		err = runImpact(args, os.Stdout)
+	case "tags":
+		if len(args) < 1 || args[0] != "list" || len(args) > 2 {
+			exitUsage()
+		}
+		kind := ""
+		if len(args) == 2 {
+			kind = args[1]
+		}
+		err = runTagsList(kind, os.Stdout)
+	case "convert":
+		if len(args) < 1 || len(args) > 2 {
+			exitUsage()
+		}
+		output := "-"
+		if len(args) == 2 {
+			output = args[1]
+		}
+		err = runConvert(args[0], output)
+	case "-h", "-help", "--help", "help":
+		fmt.Printf(usage, os.Args[0])
+		return
+	default:
+		exitUsage()
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+		os.Exit(1)
+	}
+}
+
+func exitUsage() {
+	fmt.Fprintf(os.Stderr, usage, os.Args[0])
+	os.Exit(2)
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote":  yamlQuote,
+	"indent": indent,
+}).ParseFS(templateFS, "templates/*.tmpl"))
+
+// schemaPath is the QuickStarts JSON Schema that new items are checked
+// against.
+var schemaPath = validate.SchemaPath
+
+var namePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// contentKind describes one kind of content item that new can scaffold.
+type contentKind struct {
+	name     string // Argument to new
+	title    string
+	dir      string // Directory under the content directory
+	metaKind string // kind in metadata.yml
+	template string
+}
+
+var contentKinds = []contentKind{
+	{name: "quickstart", title: "Quick start", dir: "quickstarts", metaKind: "QuickStarts", template: "quickstart.yml.tmpl"},
+	{name: "helptopic", title: "Help topic", dir: "help-topics", metaKind: "HelpTopic", template: "helptopic.yml.tmpl"},
+	{name: "learning-path", title: "Learning path", dir: "quickstarts", metaKind: "QuickStarts", template: "learning-path.yml.tmpl"},
+}
+
+// item holds the values the templates are rendered with.
+type item struct {
+	Kind        string
+	Name        string
+	TopicName   string
+	DisplayName string
+	Description string
+	Duration    int
+	URL         string
+	Tags        []models.Tag
+}
+
+func runNew(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	name := flags.String("name", "", "internal name; lowercase letters, digits and hyphens")
+	displayName := flags.String("display-name", "", "name shown to users; the title of a help topic")
+	description := flags.String("description", "", "short 2-3 sentence summary; the content of a help topic")
+	duration := flags.Int("duration", 0, "quick start duration in minutes")
+	url := flags.String("url", "", "documentation URL of a learning path")
+	bundle := flags.String("bundle", "", "bundle tag; optional for help topics")
+	dir := flags.String("dir", "docs", "content directory")
+	// The kind comes first: new quickstart -name my-quickstart.
+	var kindArg string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		kindArg, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	p := &prompter{in: bufio.NewReader(stdin), out: stdout}
+	kind, err := selectKind(p, kindArg)
+	if err != nil {
+		return err
+	}
+
+	it := item{Kind: kind.metaKind, Duration: *duration, URL: *url}
+	if it.Name, err = p.value(*name, "Name (internal only; lowercase letters, digits and hyphens)", func(s string) error {
+		if !namePattern.MatchString(s) {
+			return errors.New("name must be lowercase letters, digits and hyphens")
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	it.TopicName = it.Name
+	outDir := filepath.Join(*dir, kind.dir, it.Name)
+	if fileExists(outDir) {
+		return fmt.Errorf("%s already exists", outDir)
+	}
+	if it.DisplayName, err = p.value(*displayName, "Display name (name shown to users)", required); err != nil {
+		return err
+	}
+	if it.Description, err = p.value(*description, "Description (a short, 2-3 sentence summary)", required); err != nil {
+		return err
+	}
+	if kind.name == "quickstart" && it.Duration <= 0 {
+		value, err := p.value("", "Duration (minutes)", func(s string) error {
+			if n, err := strconv.Atoi(s); err != nil || n <= 0 {
+				return errors.New("duration must be a positive integer")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		it.Duration, _ = strconv.Atoi(value)
+	}
+	if kind.name == "learning-path" {
+		if it.URL, err = p.value(it.URL, "URL of the resource", required); err != nil {
+			return err
+		}
+	}
+	if kind.name != "helptopic" || *bundle != "" {
+		value, err := p.value(*bundle, "Bundle ("+strings.Join(models.Bundles, ", ")+")", func(s string) error {
+			if !slices.Contains(models.Bundles, s) {
+				return fmt.Errorf("unknown bundle %q", s)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		it.Tags = append(it.Tags, models.Tag{Type: models.BundleTag, Value: value})
+	}
+	if kind.name == "learning-path" {
+		it.Tags = append(it.Tags, models.Tag{Type: models.ContentType, Value: "learningPath"})
+	}
+
+	schema, err := validate.LoadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	files, err := render(kind, it)
+	if err != nil {
+		return err
+	}
+	if err := check(schema, kind, files); err != nil {
+		return err
+	}
+	if err := writeFiles(filepath.Join(*dir, kind.dir), files); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "A template %s has been created in %s. Update metadata.yml and %s.yml to reflect what the item should show.\n",
+		strings.ToLower(kind.title), outDir, it.Name)
+	return nil
+}
+
+func selectKind(p *prompter, arg string) (contentKind, error) {
+	var names []string
+	for _, kind := range contentKinds {
+		if kind.name == arg {
+			return kind, nil
+		}
+		names = append(names, kind.name)
+	}
+	if arg != "" {
+		return contentKind{}, fmt.Errorf("unknown content kind %q; use one of %s", arg, strings.Join(names, ", "))
+	}
+	for i, kind := range contentKinds {
+		fmt.Fprintf(p.out, "%d) %s\n", i+1, kind.title)
+	}
+	choice, err := p.value("", "Type", func(s string) error {
+		if n, err := strconv.Atoi(s); err != nil || n < 1 || n > len(contentKinds) {
+			return errors.New("input must be a number corresponding to an option")
+		}
+		return nil
+	})
+	if err != nil {
+		return contentKind{}, err
+	}
+	n, _ := strconv.Atoi(choice)
+	return contentKinds[n-1], nil
+}
+
+// render returns the files of a new content item, keyed by their path
+// relative to the directory of its kind.
+func render(kind contentKind, it item) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for name, tmpl := range map[string]string{"metadata.yml": "metadata.yml.tmpl", it.Name + ".yml": kind.template} {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, tmpl, it); err != nil {
+			return nil, err
+		}
+		files[filepath.Join(it.Name, name)] = buf.Bytes()
+	}
+	return files, nil
+}
+
+// check validates rendered files the way the validator checks docs/, so a
+// scaffolded item passes make validate before it is edited.
+func check(schema *jsonschema.Schema, kind contentKind, files map[string][]byte) error {
+	base, err := os.MkdirTemp("", "quickstarts-new-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(base)
+	if err := writeFiles(filepath.Join(base, kind.dir), files); err != nil {
+		return err
+	}
+	r := validate.Content(base, schema, validate.Options{})
+	if r.Count(validate.SeverityError) == 0 {
+		return nil
+	}
+	var problems []string
+	for _, d := range r.Diagnostics {
+		problems = append(problems, strings.TrimPrefix(d.String(), base+string(filepath.Separator)))
+	}
+	return fmt.Errorf("the new item does not validate:\n%s", strings.Join(problems, "\n"))
+}
+
+func writeFiles(base string, files map[string][]byte) error {
+	for name, content := range files {
+		path := filepath.Join(base, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// prompter asks for the values that were not given as flags.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// value returns given when it is set, and otherwise asks for a value until
+// check accepts it.
+func (p *prompter) value(given, label string, check func(string) error) (string, error) {
+	if given != "" {
+		return given, check(given)
+	}
+	for {
+		fmt.Fprintf(p.out, "%s: ", label)
+		line, err := p.in.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line != "" {
+			checkErr := check(line)
+			if checkErr == nil {
+				return line, nil
+			}
+			fmt.Fprintln(p.out, checkErr)
+		}
+		if err != nil {
+			return "", fmt.Errorf("no value for %q", label)
+		}
+	}
+}
+
+func required(s string) error {
+	if s == "" {
+		return errors.New("a value is required")
+	}
+	return nil
+}
+
+// yamlQuote returns s as a single-quoted YAML scalar.
+func yamlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// indent indents every non-empty line of s by n spaces, for block scalars.
+func indent(n int, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat(" ", n) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	schemaPath = "../../spec/quickstart.schema.json"
+}
+
+func TestTemplatesValidate(t *testing.T) {
+	schema, err := validate.LoadSchema(schemaPath)
+	require.NoError(t, err)
+
+	for _, kind := range contentKinds {
+		t.Run(kind.name, func(t *testing.T) {
+			it := item{
+				Kind:        kind.metaKind,
+				Name:        "new-item",
+				TopicName:   "new-item",
+				DisplayName: "It's: a #test",
+				Description: "First line: with a colon.\n\n- A list item\n'quoted'",
+				Duration:    5,
+				URL:         "https://docs.redhat.com/en/documentation",
+				Tags:        []models.Tag{{Type: models.BundleTag, Value: "insights"}},
+			}
+			files, err := render(kind, it)
+			require.NoError(t, err)
+			assert.NoError(t, check(schema, kind, files))
+		})
+	}
+}
+
+func TestCheckReportsInvalidItems(t *testing.T) {
+	schema, err := validate.LoadSchema(schemaPath)
+	require.NoError(t, err)
+
+	kind := contentKinds[0]
+	files, err := render(kind, item{Kind: kind.metaKind, Name: "new-item", DisplayName: "New", Description: "New", Duration: 5,
+		Tags: []models.Tag{{Type: models.BundleTag, Value: "insigts"}}})
+	require.NoError(t, err)
+	err = check(schema, kind, files)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `quickstarts/new-item/metadata.yml:5:12: error: /tags/0/value: unknown bundle tag "insigts"; did you mean "insights"? [tag]`)
+}
+
+func TestRunNew(t *testing.T) {
+	t.Run("from flags", func(t *testing.T) {
+		dir := t.TempDir()
+		var out bytes.Buffer
+		err := runNew([]string{"quickstart", "-dir", dir, "-name", "from-flags", "-display-name", "From flags",
+			"-description", "Created from flags.", "-duration", "10", "-bundle", "iam"}, strings.NewReader(""), &out)
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(dir, "quickstarts", "from-flags", "from-flags.yml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "durationMinutes: 10")
+		assert.FileExists(t, filepath.Join(dir, "quickstarts", "from-flags", "metadata.yml"))
+
+		err = runNew([]string{"quickstart", "-dir", dir, "-name", "from-flags"}, strings.NewReader(""), &out)
+		assert.ErrorContains(t, err, "already exists")
+	})
+
+	t.Run("interactively", func(t *testing.T) {
+		dir := t.TempDir()
+		// An invalid answer is asked again.
+		input := "2\nNot Valid\nmy-topic\nMy topic\nExplains the topic.\n"
+		var out bytes.Buffer
+		require.NoError(t, runNew([]string{"-dir", dir}, strings.NewReader(input), &out))
+		assert.Contains(t, out.String(), "name must be lowercase letters, digits and hyphens")
+		content, err := os.ReadFile(filepath.Join(dir, "help-topics", "my-topic", "my-topic.yml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "title: 'My topic'")
+	})
+
+	t.Run("without input", func(t *testing.T) {
+		err := runNew([]string{"learning-path", "-dir", t.TempDir(), "-name", "no-input"}, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "no value")
+	})
+}
+
+func TestConvertYAML(t *testing.T) {
+	out, err := convertYAML([]byte("name: test\nitems:\n  - 1\n  - two\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"items\": [\n    1,\n    \"two\"\n  ],\n  \"name\": \"test\"\n}", string(out))
+
+	_, err = convertYAML([]byte("name: [unclosed"))
+	assert.Error(t, err)
+}
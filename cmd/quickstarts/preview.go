@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"syscall"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/preview"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+)
+
+var metadataPattern = regexp.MustCompile(`^metadata\.ya?ml$`)
+
+// runPreview prints the content item given as an argument, or serves the
+// whole content directory without one.
+func runPreview(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("preview", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8000", "address to serve the API on")
+	dir := flags.String("dir", "docs", "content directory")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check the content directory for changes")
+	checkLinks := flags.Bool("check-links", false, "also check external URLs against the default domain allow-list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	switch flags.NArg() {
+	case 0:
+		return servePreview(*addr, *dir, *interval, *checkLinks)
+	case 1:
+		return printPreview(flags.Arg(0), w)
+	default:
+		return fmt.Errorf("unexpected argument %q", flags.Arg(1))
+	}
+}
+
+// servePreview serves the quickstarts API from dir until interrupted. It
+// re-validates the content whenever a file changes and reports the result
+// to clients of /preview/events.
+func servePreview(addr, dir string, interval time.Duration, checkLinks bool) error {
+	config.Init()
+	schema, err := validate.LoadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	var opts validate.Options
+	if checkLinks {
+		opts.Links = &validate.LinkPolicy{Domains: validate.DefaultLinkDomains}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := preview.New(dir, schema, opts)
+	status := server.Reload()
+	fmt.Fprintf(os.Stderr, "Serving %d quickstarts and %d help topics from %s on http://%s/api/quickstarts/v1 (%d errors, %d warnings)\n",
+		status.Quickstarts, status.HelpTopics, dir, addr, status.Errors, status.Warnings)
+	fmt.Fprintf(os.Stderr, "Validation updates stream from http://%s/preview/events\n", addr)
+	go server.Watch(ctx, interval)
+	return server.ListenAndServe(ctx, addr)
+}
+
+// printPreview prints the content item in path, a content directory or its
+// metadata file, as the API returns it once seeded.
+func printPreview(path string, w io.Writer) error {
+	metadataFile := path
+	if info, err := os.Stat(path); err != nil {
+		return err
+	} else if info.IsDir() {
+		matches, _ := filepath.Glob(filepath.Join(path, "metadata.y*"))
+		if len(matches) == 0 {
+			return fmt.Errorf("%s has no metadata.yml", path)
+		}
+		metadataFile = matches[0]
+	} else if !metadataPattern.MatchString(filepath.Base(path)) {
+		return fmt.Errorf("%s is not a content directory or metadata file", path)
+	}
+
+	item, err := database.Preview(metadataFile)
+	if err != nil {
+		return err
+	}
+	var data interface{}
+	switch item := item.(type) {
+	case models.Quickstart:
+		data = item.ToAPI()
+	case []models.HelpTopic:
+		topics := make([]generated.HelpTopic, len(item))
+		for i, topic := range item {
+			topics[i] = topic.ToAPI()
+		}
+		data = topics
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{"data": data})
+}
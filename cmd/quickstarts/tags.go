@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+)
+
+// runTagsList prints every tag kind with the values the validator accepts,
+// or only those of kind.
+func runTagsList(kind string, w io.Writer) error {
+	taxonomy := validate.TagTaxonomy()
+	found := false
+	for _, k := range models.TagType("").GetAllTags() {
+		if kind != "" && string(k) != kind {
+			continue
+		}
+		found = true
+		values, ok := taxonomy[k]
+		if !ok {
+			fmt.Fprintf(w, "%s: any value\n", k)
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n  %s\n", k, strings.Join(values, "\n  "))
+	}
+	if !found {
+		return fmt.Errorf("unknown tag kind %q", kind)
+	}
+	return nil
+}
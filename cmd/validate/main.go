@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+)
+
+func main() {
+	os.Exit(validate.Command(os.Args[0], os.Args[1:], os.Stdout, os.Stderr))
+}
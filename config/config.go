@@ -4,28 +4,50 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	clowder "github.com/redhatinsights/app-common-go/pkg/api/v1"
 	"github.com/sirupsen/logrus"
 )
 
 type QuickstartsConfig struct {
-	ServerAddr             string
-	OpenApiSpecPath        string
-	DbHost                 string
-	DbUser                 string
-	DbPassword             string
-	DbPort                 int
-	DbName                 string
-	MetricsPort            int
-	Test                   bool
-	TestDatabaseURL        string // PostgreSQL DSN for tests; when set, tests use PG instead of SQLite
-	DbSSLMode              string
-	DbSSLRootCert          string
-	LogLevel               string
-	MaxFuzzySearchDistance int // Max Levenshtein distance for fuzzy search (typo tolerance)
-	GitServiceURL          string
-	PSKToken               string
+	ServerAddr                  string
+	OpenApiSpecPath             string
+	DbHost                      string
+	DbUser                      string
+	DbPassword                  string
+	DbPort                      int
+	DbName                      string
+	MetricsPort                 int
+	Test                        bool
+	TestDatabaseURL             string // PostgreSQL DSN for tests; when set, tests use PG instead of SQLite
+	DbSSLMode                   string
+	DbSSLRootCert               string
+	LogLevel                    string
+	MaxFuzzySearchDistance      int // Max Levenshtein distance for fuzzy search (typo tolerance)
+	GitServiceURL               string
+	PSKToken                    string
+	ContentCacheEnabled         bool          // Serve catalog reads from the in-process content cache
+	ContentCacheRefreshInterval time.Duration // How often the cache polls the seed generation marker
+	OpenAPIValidationEnabled    bool          // Reject requests that do not match the OpenAPI spec
+	OpenAPIResponseValidation   string        // "off", "warn" (log drift) or "strict" (fail on drift)
+	ShutdownDrainDelay          time.Duration // How long /readyz reports draining before the server stops accepting connections
+	TracesExporter              string        // "none", "otlp" or "stdout"
+	DbMaxOpenConns              int           // Maximum open connections per pool; 0 means unlimited
+	DbMaxIdleConns              int           // Maximum idle connections kept per pool
+	DbConnMaxLifetime           time.Duration // Connections older than this are closed and reopened
+	DbStatementTimeout          time.Duration // PostgreSQL statement_timeout; 0 leaves the server default
+	DbReadReplicaDSNs           []string      // PostgreSQL DSNs that serve catalog reads
+	AdminRoles                  []string      // Associate roles allowed to call /admin endpoints; empty disables them
+	ContentGitURL               string        // Repository to seed content from instead of the local content directory
+	ContentGitRef               string        // Branch, tag or commit SHA of ContentGitURL to seed
+	ContentGitToken             string        // Token for cloning a private ContentGitURL
+	ContentArchive              string        // tar or tar.gz file to seed content from
+	ContentSourcePath           string        // Content directory inside the repository or archive
+	SeedStrict                  bool          // Abort seeding when any content file is invalid
+	SeedErrorBudget             int           // Invalid content files tolerated when not strict; negative means unlimited
+	SeedRunRetention            int           // Newest seed runs kept in seed_runs; 0 keeps all of them
 }
 
 var config *QuickstartsConfig
@@ -34,6 +56,9 @@ func Init() {
 	config = &QuickstartsConfig{}
 	config.ServerAddr = ":8000"
 	config.OpenApiSpecPath = "./spec/openapi.json"
+	if specPath := os.Getenv("OPENAPI_SPEC_PATH"); specPath != "" {
+		config.OpenApiSpecPath = specPath
+	}
 	config.Test = false
 	// Log level will default to "Error". Level should be one of
 	// info or debug or error
@@ -103,6 +128,180 @@ func Init() {
 	}
 
 	config.PSKToken = os.Getenv("PSK_TOKEN")
+
+	config.ContentCacheEnabled = os.Getenv("CONTENT_CACHE_ENABLED") == "true"
+	config.ContentCacheRefreshInterval = 30 * time.Second
+	if interval, ok := os.LookupEnv("CONTENT_CACHE_REFRESH_INTERVAL"); ok {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			logrus.Warnf(
+				"Invalid CONTENT_CACHE_REFRESH_INTERVAL=%q: must be a positive duration; using default %s",
+				interval,
+				config.ContentCacheRefreshInterval,
+			)
+		} else {
+			config.ContentCacheRefreshInterval = d
+		}
+	}
+
+	// One readiness probe period, so the pod is out of rotation before its
+	// listener closes.
+	config.ShutdownDrainDelay = 5 * time.Second
+	if value, ok := os.LookupEnv("SHUTDOWN_DRAIN_DELAY"); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			logrus.Warnf(
+				"Invalid SHUTDOWN_DRAIN_DELAY=%q: must be a non-negative duration; using default %s",
+				value,
+				config.ShutdownDrainDelay,
+			)
+		} else {
+			config.ShutdownDrainDelay = d
+		}
+	}
+
+	config.OpenAPIValidationEnabled = os.Getenv("OPENAPI_VALIDATION_ENABLED") != "false"
+	config.OpenAPIResponseValidation = "off"
+	if mode, ok := os.LookupEnv("OPENAPI_RESPONSE_VALIDATION"); ok {
+		switch mode {
+		case "off", "warn", "strict":
+			config.OpenAPIResponseValidation = mode
+		default:
+			logrus.Warnf(
+				"Invalid OPENAPI_RESPONSE_VALIDATION=%q: must be off, warn or strict; using default %s",
+				mode,
+				config.OpenAPIResponseValidation,
+			)
+		}
+	}
+
+	config.DbMaxOpenConns = 20
+	if value, ok := os.LookupEnv("DB_MAX_OPEN_CONNS"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logrus.Warnf(
+				"Invalid DB_MAX_OPEN_CONNS=%q: must be a non-negative integer; using default %d",
+				value,
+				config.DbMaxOpenConns,
+			)
+		} else {
+			config.DbMaxOpenConns = n
+		}
+	}
+
+	config.DbMaxIdleConns = 10
+	if value, ok := os.LookupEnv("DB_MAX_IDLE_CONNS"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logrus.Warnf(
+				"Invalid DB_MAX_IDLE_CONNS=%q: must be a non-negative integer; using default %d",
+				value,
+				config.DbMaxIdleConns,
+			)
+		} else {
+			config.DbMaxIdleConns = n
+		}
+	}
+
+	config.DbConnMaxLifetime = 30 * time.Minute
+	if value, ok := os.LookupEnv("DB_CONN_MAX_LIFETIME"); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			logrus.Warnf(
+				"Invalid DB_CONN_MAX_LIFETIME=%q: must be a non-negative duration; using default %s",
+				value,
+				config.DbConnMaxLifetime,
+			)
+		} else {
+			config.DbConnMaxLifetime = d
+		}
+	}
+
+	// An empty value leaves the server default, matching the ClowdApp parameter.
+	if value := os.Getenv("DB_STATEMENT_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			logrus.Warnf(
+				"Invalid DB_STATEMENT_TIMEOUT=%q: must be a non-negative duration; using default %s",
+				value,
+				config.DbStatementTimeout,
+			)
+		} else {
+			config.DbStatementTimeout = d
+		}
+	}
+
+	// Replica DSNs are separated by commas, so they cannot contain one.
+	if value := os.Getenv("DB_READ_REPLICA_DSNS"); value != "" {
+		for _, dsn := range strings.Split(value, ",") {
+			if dsn = strings.TrimSpace(dsn); dsn != "" {
+				config.DbReadReplicaDSNs = append(config.DbReadReplicaDSNs, dsn)
+			}
+		}
+	}
+
+	if value := os.Getenv("ADMIN_ROLES"); value != "" {
+		for _, role := range strings.Split(value, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				config.AdminRoles = append(config.AdminRoles, role)
+			}
+		}
+	}
+
+	config.ContentGitURL = os.Getenv("CONTENT_GIT_URL")
+	config.ContentGitRef = "main"
+	if ref := os.Getenv("CONTENT_GIT_REF"); ref != "" {
+		config.ContentGitRef = ref
+	}
+	config.ContentGitToken = os.Getenv("CONTENT_GIT_TOKEN")
+	config.ContentArchive = os.Getenv("CONTENT_ARCHIVE")
+	config.ContentSourcePath = "docs"
+	if path, ok := os.LookupEnv("CONTENT_SOURCE_PATH"); ok {
+		config.ContentSourcePath = path
+	}
+
+	config.SeedStrict = os.Getenv("SEED_STRICT") == "true"
+	config.SeedErrorBudget = -1
+	if value := os.Getenv("SEED_ERROR_BUDGET"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			logrus.Warnf(
+				"Invalid SEED_ERROR_BUDGET=%q: must be an integer; using default %d",
+				value,
+				config.SeedErrorBudget,
+			)
+		} else {
+			config.SeedErrorBudget = n
+		}
+	}
+
+	config.SeedRunRetention = 100
+	if value, ok := os.LookupEnv("SEED_RUN_RETENTION"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logrus.Warnf(
+				"Invalid SEED_RUN_RETENTION=%q: must be a non-negative integer; using default %d",
+				value,
+				config.SeedRunRetention,
+			)
+		} else {
+			config.SeedRunRetention = n
+		}
+	}
+
+	config.TracesExporter = "none"
+	if exporter, ok := os.LookupEnv("OTEL_TRACES_EXPORTER"); ok {
+		switch exporter {
+		case "none", "otlp", "stdout":
+			config.TracesExporter = exporter
+		default:
+			logrus.Warnf(
+				"Invalid OTEL_TRACES_EXPORTER=%q: must be none, otlp or stdout; using default %s",
+				exporter,
+				config.TracesExporter,
+			)
+		}
+	}
 }
 
 // Get returns a quickstarts service configuration
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/database"
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
 	"github.com/RedHatInsights/quickstarts/pkg/logger"
 	qsmiddleware "github.com/RedHatInsights/quickstarts/pkg/middleware"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 	"github.com/RedHatInsights/quickstarts/pkg/routes"
 	"github.com/RedHatInsights/quickstarts/pkg/securitylog"
+	"github.com/RedHatInsights/quickstarts/pkg/services"
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
@@ -21,8 +29,22 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func initDependecies() {
+const apiBasePath = "/api/quickstarts/v1"
+
+// shutdownTimeout bounds how long in-flight requests may take to finish after
+// SIGTERM before the servers are closed.
+const shutdownTimeout = 10 * time.Second
+
+func initDependecies(ctx context.Context, cfg *config.QuickstartsConfig) {
 	database.Init()
+	if err := database.InitReadReplicas(); err != nil {
+		logrus.WithError(err).Error("Failed to configure read replicas, serving reads from the primary")
+	}
+	if cfg.ContentCacheEnabled {
+		if err := services.InitContentCache(ctx, database.DB, cfg.ContentCacheRefreshInterval); err != nil {
+			logrus.WithError(err).Error("Failed to load content cache, serving catalog from the database")
+		}
+	}
 }
 
 func probe(w http.ResponseWriter, r *http.Request) {
@@ -45,8 +67,15 @@ func main() {
 	godotenv.Load()
 	config.Init()
 	cfg := config.Get()
-	initDependecies()
+	appCtx, cancelApp := context.WithCancel(context.Background())
+	defer cancelApp()
+	initDependecies(appCtx, cfg)
 	setupGlobalLogger(cfg)
+	shutdownTracing, err := tracing.Init(appCtx, "quickstarts", cfg.TracesExporter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to initialize tracing, spans will not be exported")
+		shutdownTracing = func(context.Context) error { return nil }
+	}
 	logrus.WithFields(logrus.Fields{
 		"ServerAddr": cfg.ServerAddr,
 		"Mode":       "oapi-codegen",
@@ -56,9 +85,11 @@ func main() {
 	mr := chi.NewRouter()
 
 	routerLogger := logrus.New()
+	routerLogger.AddHook(tracing.LogrusHook{})
 
 	r.Use(
-		request_id.ConfiguredRequestID("x-rh-insights-request-id"),
+		request_id.ConfiguredRequestID(utils.RequestIDHeader),
+		tracing.Middleware,
 		middleware.RealIP,
 		middleware.Recoverer,
 		middleware.RequestLogger(logger.NewLogger(cfg, routerLogger)),
@@ -66,13 +97,32 @@ func main() {
 	)
 
 	// Create the adapter that implements the generated ServerInterface
-	serverAdapter := routes.NewServerAdapter()
-
-	// Create a sub-router with Prometheus middleware
-	apiRouter := r.With(routes.PrometheusMiddleware)
+	repos := repository.NewGorm(database.DB, database.IsFuzzySearchSupported())
+	serverAdapter := routes.NewServerAdapter(repos)
+
+	// Create a sub-router with Prometheus, response compression and spec
+	// validation middleware
+	apiMiddleware := []func(http.Handler) http.Handler{routes.PrometheusMiddleware, qsmiddleware.Compress()}
+	if cfg.OpenAPIValidationEnabled {
+		validator, err := qsmiddleware.OpenAPIValidator(cfg.OpenApiSpecPath, qsmiddleware.OpenAPIValidatorOptions{
+			BasePath:          apiBasePath,
+			ValidateResponses: cfg.OpenAPIResponseValidation != "off",
+			Strict:            cfg.OpenAPIResponseValidation == "strict",
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Failed to load OpenAPI spec, request validation disabled")
+		} else {
+			apiMiddleware = append(apiMiddleware, validator)
+		}
+	}
+	apiRouter := r.With(apiMiddleware...)
 
 	// Use the generated handler with our adapter
-	generated.HandlerFromMuxWithBaseURL(serverAdapter, apiRouter, "/api/quickstarts/v1")
+	generated.HandlerWithOptions(serverAdapter, generated.ChiServerOptions{
+		BaseURL:          apiBasePath,
+		BaseRouter:       apiRouter,
+		ErrorHandlerFunc: routes.ParamErrorHandler,
+	})
 
 	if os.Getenv("PSK_TOKEN") != "" {
 		logrus.Info("PSK_TOKEN is set, git-service proxy endpoints enabled")
@@ -88,44 +138,67 @@ func main() {
 	mr.Handle("/metrics", promhttp.Handler())
 	r.Get("/test", probe)
 
-	server := http.Server{
+	// Kubernetes probes: liveness only reports the process is up, readiness
+	// also checks the database, seeded content and git-service.
+	health := routes.NewHealth(serverAdapter.HealthChecks()...)
+	r.Get("/livez", health.Livez)
+	r.Get("/readyz", health.Readyz)
+
+	server := &http.Server{
 		Addr:    cfg.ServerAddr,
 		Handler: r,
 	}
 
-	metricsServer := http.Server{
+	metricsServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.MetricsPort),
 		Handler: mr,
 	}
 
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
 	go func() {
 		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logrus.Fatal("Metrics server stopped")
 		}
 	}()
 
-	securitylog.LogStartup("quickstarts", cfg.ServerAddr)
-	logrus.Infoln("Starting http server")
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		securitylog.LogShutdown("quickstarts", "failure", err.Error())
-		logrus.Fatal("Api server has stopped")
-	}
-	securitylog.LogShutdown("quickstarts", "success", "")
-
-	// <-done
-	// logrus.Info("Gracefully stopping server")
+	go func() {
+		securitylog.LogStartup("quickstarts", cfg.ServerAddr)
+		logrus.Infoln("Starting http server")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			securitylog.LogShutdown("quickstarts", "failure", err.Error())
+			logrus.Fatal("Api server has stopped")
+		}
+	}()
 
-	// ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	<-done
+	logrus.Info("Gracefully stopping server")
+	health.Drain()
+	// Keep serving until the readiness probe has seen the pod draining and
+	// the load balancer has stopped sending it new requests.
+	time.Sleep(cfg.ShutdownDrainDelay)
 
-	// defer func() {
-	// 	// extra handling here
-	// 	cancel()
-	// }()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		securitylog.LogShutdown("quickstarts", "failure", err.Error())
+		logrus.WithError(err).Error("Api server shutdown failed")
+	} else {
+		securitylog.LogShutdown("quickstarts", "success", "")
+	}
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		logrus.WithError(err).Error("Metrics server shutdown failed")
+	}
+	cancelApp()
+	if err := shutdownTracing(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to flush traces")
+	}
 
-	// if err := server.Shutdown(ctx); err != nil {
-	// 	logrus.Fatal("Server shutdown failed:%+v", err)
-	// }
-	// logrus.Info("Server stypped properly")
+	if sqlDB, err := database.DB.DB(); err == nil {
+		sqlDB.Close()
+	}
+	logrus.Info("Server stopped")
 }
 
 func setupGlobalLogger(opts *config.QuickstartsConfig) {
@@ -134,4 +207,5 @@ func setupGlobalLogger(opts *config.QuickstartsConfig) {
 		logLevel = logrus.ErrorLevel
 	}
 	logrus.SetLevel(logLevel)
+	logrus.AddHook(tracing.LogrusHook{})
 }
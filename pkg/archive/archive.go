@@ -0,0 +1,300 @@
+// Package archive reads and writes content archives: JSON Lines streams that
+// hold the catalog (tags, quickstarts, help topics with their tag
+// associations and lifecycle, and the previous names of renamed quickstarts)
+// and, optionally, user data (favorites and progress).
+//
+// The first line is a header carrying the format version. Every other line is
+// one record. Records reference each other by name and tag type/value rather
+// than by database ID, so an archive can be loaded into any environment.
+// Readers accept gzip-compressed archives transparently.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+)
+
+// Version is the archive format version written by this build. Readers
+// reject archives with a newer version.
+//
+// Version 2 added the lifecycle and source of quickstarts and help topics,
+// and alias records. Items in version 1 archives are published.
+const Version = 2
+
+// ContentType is the media type of an uncompressed archive.
+const ContentType = "application/x-ndjson"
+
+// ErrInvalid is wrapped by every error caused by malformed archive contents,
+// as opposed to failures reading the underlying stream or storing records.
+var ErrInvalid = errors.New("invalid archive")
+
+// Kind identifies the type of a record.
+type Kind string
+
+const (
+	KindHeader     Kind = "header"
+	KindTag        Kind = "tag"
+	KindQuickstart Kind = "quickstart"
+	KindHelpTopic  Kind = "helpTopic"
+	KindAlias      Kind = "alias"
+	KindFavorite   Kind = "favorite"
+	KindProgress   Kind = "progress"
+)
+
+// UserData reports whether records of kind k hold user data rather than
+// catalog content.
+func (k Kind) UserData() bool {
+	return k == KindFavorite || k == KindProgress
+}
+
+// Header is the first record of every archive. Source and Revision carry the
+// provenance of the exported content, when known.
+type Header struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	UserData   bool      `json:"userData"`
+	Source     string    `json:"source,omitempty"`
+	Revision   string    `json:"revision,omitempty"`
+}
+
+// Tag identifies a tag by type and value.
+type Tag struct {
+	Type  models.TagType `json:"type"`
+	Value string         `json:"value"`
+}
+
+// Quickstart is an archived quickstart with its tags, lifecycle and the
+// content file it was seeded from.
+type Quickstart struct {
+	Name           string          `json:"name"`
+	Content        json.RawMessage `json:"content,omitempty"`
+	Tags           []Tag           `json:"tags,omitempty"`
+	SourceFile     string          `json:"sourceFile,omitempty"`
+	SourceRevision string          `json:"sourceRevision,omitempty"`
+	models.Lifecycle
+}
+
+// HelpTopic is an archived help topic with its tags and lifecycle.
+type HelpTopic struct {
+	Name      string          `json:"name"`
+	GroupName string          `json:"groupName"`
+	Content   json.RawMessage `json:"content,omitempty"`
+	Tags      []Tag           `json:"tags,omitempty"`
+	models.Lifecycle
+}
+
+// Alias is a previous name of a renamed quickstart.
+type Alias struct {
+	Name           string `json:"name"`
+	QuickstartName string `json:"quickstartName"`
+}
+
+// Favorite is an archived favorite, keyed by account and quickstart name.
+type Favorite struct {
+	AccountId      string `json:"accountId"`
+	QuickstartName string `json:"quickstartName"`
+	Favorite       bool   `json:"favorite"`
+}
+
+// Progress is an archived progress record, keyed by account and quickstart name.
+type Progress struct {
+	AccountId      int             `json:"accountId"`
+	QuickstartName string          `json:"quickstartName"`
+	Progress       json.RawMessage `json:"progress,omitempty"`
+}
+
+// Record is one line of an archive. Exactly one of the pointer fields, the
+// one matching Kind, is set.
+type Record struct {
+	Kind       Kind        `json:"kind"`
+	Header     *Header     `json:"header,omitempty"`
+	Tag        *Tag        `json:"tag,omitempty"`
+	Quickstart *Quickstart `json:"quickstart,omitempty"`
+	HelpTopic  *HelpTopic  `json:"helpTopic,omitempty"`
+	Alias      *Alias      `json:"alias,omitempty"`
+	Favorite   *Favorite   `json:"favorite,omitempty"`
+	Progress   *Progress   `json:"progress,omitempty"`
+}
+
+// Options selects what an export writes or an import loads.
+type Options struct {
+	// UserData includes favorites and progress.
+	UserData bool
+}
+
+// Counts reports how many records of each kind an export wrote or an import
+// loaded. Skipped counts records an import could not apply, such as
+// favorites for quickstarts that do not exist.
+type Counts struct {
+	Tags        int `json:"tags"`
+	Quickstarts int `json:"quickstarts"`
+	HelpTopics  int `json:"helpTopics"`
+	Aliases     int `json:"aliases"`
+	Favorites   int `json:"favorites"`
+	Progress    int `json:"progress"`
+	Skipped     int `json:"skipped"`
+}
+
+// Add counts one record of kind k.
+func (c *Counts) Add(k Kind) {
+	switch k {
+	case KindTag:
+		c.Tags++
+	case KindQuickstart:
+		c.Quickstarts++
+	case KindHelpTopic:
+		c.HelpTopics++
+	case KindAlias:
+		c.Aliases++
+	case KindFavorite:
+		c.Favorites++
+	case KindProgress:
+		c.Progress++
+	}
+}
+
+// Writer writes records to an archive.
+type Writer struct {
+	enc *json.Encoder
+}
+
+// NewWriter writes the header to w and returns a Writer for the records that
+// follow. The header version is always set to Version.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Version = Version
+	aw := &Writer{enc: json.NewEncoder(w)}
+	if err := aw.enc.Encode(Record{Kind: KindHeader, Header: &header}); err != nil {
+		return nil, fmt.Errorf("write archive header: %w", err)
+	}
+	return aw, nil
+}
+
+// Write appends one record.
+func (w *Writer) Write(r Record) error {
+	return w.enc.Encode(r)
+}
+
+// Reader reads records from an archive.
+type Reader struct {
+	dec    *json.Decoder
+	Header Header
+}
+
+// NewReader reads and checks the archive header from r, decompressing it
+// first if it is gzipped.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+		}
+		src = gz
+	}
+
+	ar := &Reader{dec: json.NewDecoder(src)}
+	var first Record
+	if err := ar.dec.Decode(&first); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: archive is empty", ErrInvalid)
+		}
+		return nil, decodeError("header", err)
+	}
+	if first.Kind != KindHeader || first.Header == nil {
+		return nil, fmt.Errorf("%w: archive must start with a header record, got %q", ErrInvalid, first.Kind)
+	}
+	if first.Header.Version < 1 || first.Header.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported archive version %d (supported: 1 to %d)", ErrInvalid, first.Header.Version, Version)
+	}
+	ar.Header = *first.Header
+	return ar, nil
+}
+
+// Next returns the next record, or io.EOF at the end of the archive.
+func (r *Reader) Next() (Record, error) {
+	var rec Record
+	if err := r.dec.Decode(&rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return rec, io.EOF
+		}
+		return rec, decodeError("record", err)
+	}
+	if err := rec.validate(); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
+
+func (r Record) validate() error {
+	var ok bool
+	switch r.Kind {
+	case KindTag:
+		ok = r.Tag != nil
+	case KindQuickstart:
+		ok = r.Quickstart != nil && r.Quickstart.Name != ""
+	case KindHelpTopic:
+		ok = r.HelpTopic != nil && r.HelpTopic.Name != ""
+	case KindAlias:
+		ok = r.Alias != nil && r.Alias.Name != "" && r.Alias.QuickstartName != ""
+	case KindFavorite:
+		ok = r.Favorite != nil
+	case KindProgress:
+		ok = r.Progress != nil
+	default:
+		return fmt.Errorf("%w: unknown record kind %q", ErrInvalid, r.Kind)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s record is missing its %s field", ErrInvalid, r.Kind, r.Kind)
+	}
+
+	var tags []Tag
+	var status string
+	switch r.Kind {
+	case KindTag:
+		tags = []Tag{*r.Tag}
+	case KindQuickstart:
+		tags, status = r.Quickstart.Tags, r.Quickstart.Status
+	case KindHelpTopic:
+		tags, status = r.HelpTopic.Tags, r.HelpTopic.Status
+	}
+	switch status {
+	case "", models.StatusDraft, models.StatusPublished, models.StatusDeprecated:
+	default:
+		return fmt.Errorf("%w: %s %q has unknown status %q", ErrInvalid, r.Kind, r.name(), status)
+	}
+	for _, t := range tags {
+		if !t.Type.IsValidTag() || t.Value == "" {
+			return fmt.Errorf("%w: invalid tag %q/%q", ErrInvalid, t.Type, t.Value)
+		}
+	}
+	return nil
+}
+
+func (r Record) name() string {
+	switch r.Kind {
+	case KindQuickstart:
+		return r.Quickstart.Name
+	case KindHelpTopic:
+		return r.HelpTopic.Name
+	}
+	return ""
+}
+
+// decodeError wraps JSON syntax and type errors in ErrInvalid and returns
+// read errors unchanged.
+func decodeError(what string, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %s: %s", ErrInvalid, what, err.Error())
+	}
+	return fmt.Errorf("read archive %s: %w", what, err)
+}
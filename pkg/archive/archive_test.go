@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArchive(t *testing.T, records ...Record) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{ExportedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), UserData: true})
+	require.NoError(t, err)
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+	return buf.Bytes()
+}
+
+func readAll(t *testing.T, r io.Reader) (Header, []Record, error) {
+	t.Helper()
+	ar, err := NewReader(r)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	var records []Record
+	for {
+		rec, err := ar.Next()
+		if err == io.EOF {
+			return ar.Header, records, nil
+		}
+		if err != nil {
+			return ar.Header, records, err
+		}
+		records = append(records, rec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	records := []Record{
+		{Kind: KindTag, Tag: &Tag{Type: models.BundleTag, Value: "rhel"}},
+		{Kind: KindQuickstart, Quickstart: &Quickstart{Name: "qs", Content: json.RawMessage(`{"spec":{}}`), Tags: []Tag{{Type: models.BundleTag, Value: "rhel"}}, Lifecycle: models.Lifecycle{Status: models.StatusDraft}}},
+		{Kind: KindAlias, Alias: &Alias{Name: "old-qs", QuickstartName: "qs"}},
+		{Kind: KindFavorite, Favorite: &Favorite{AccountId: "1", QuickstartName: "qs", Favorite: true}},
+	}
+	data := writeArchive(t, records...)
+	assert.Equal(t, 5, bytes.Count(data, []byte("\n")), "one line per record plus the header")
+
+	t.Run("plain", func(t *testing.T) {
+		header, got, err := readAll(t, bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, Version, header.Version)
+		assert.True(t, header.UserData)
+		assert.Equal(t, records, got)
+	})
+
+	t.Run("gzipped", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write(data)
+		require.NoError(t, gz.Close())
+
+		_, got, err := readAll(t, &buf)
+		require.NoError(t, err)
+		assert.Equal(t, records, got)
+	})
+}
+
+func TestReaderRejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "archive is empty"},
+		{"no header", `{"kind":"tag","tag":{"type":"bundle","value":"rhel"}}`, "must start with a header"},
+		{"newer version", `{"kind":"header","header":{"version":99}}`, "unsupported archive version 99"},
+		{"not json", "{oops", "invalid archive: header"},
+		{"unknown kind", `{"kind":"header","header":{"version":1}}` + "\n" + `{"kind":"widget"}`, `unknown record kind "widget"`},
+		{"missing payload", `{"kind":"header","header":{"version":1}}` + "\n" + `{"kind":"quickstart"}`, "quickstart record is missing"},
+		{"invalid tag", `{"kind":"header","header":{"version":1}}` + "\n" + `{"kind":"tag","tag":{"type":"nonsense","value":"x"}}`, `invalid tag "nonsense"/"x"`},
+		{"invalid status", `{"kind":"header","header":{"version":2}}` + "\n" + `{"kind":"quickstart","quickstart":{"name":"qs","status":"hidden"}}`, `quickstart "qs" has unknown status "hidden"`},
+		{"alias without target", `{"kind":"header","header":{"version":2}}` + "\n" + `{"kind":"alias","alias":{"name":"old"}}`, "alias record is missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := readAll(t, strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalid)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestKindUserData(t *testing.T) {
+	assert.True(t, KindFavorite.UserData())
+	assert.True(t, KindProgress.UserData())
+	assert.False(t, KindQuickstart.UserData())
+	assert.False(t, KindTag.UserData())
+}
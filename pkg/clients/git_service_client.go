@@ -8,9 +8,15 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
+	"github.com/redhatinsights/platform-go-middlewares/request_id"
 )
 
+const requestIDHeader = "x-rh-insights-request-id"
+
 type GitService struct {
 	baseURL    string
 	httpClient *http.Client
@@ -21,7 +27,8 @@ func NewGitService(baseURL, pskToken string) *GitService {
 	return &GitService{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout:   60 * time.Second,
+			Transport: tracing.Transport(nil),
 		},
 		pskToken: pskToken,
 	}
@@ -55,11 +62,67 @@ type gitServiceRequest struct {
 	Metadata GitServiceMetadata `json:"metadata"`
 }
 
+// gitServiceError is the error body older git-service versions return.
 type gitServiceError struct {
 	Status string `json:"status"`
 	Msg    string `json:"msg"`
 }
 
+// gitServiceProblem is the RFC 7807 error body current git-service versions return.
+type gitServiceProblem struct {
+	Detail string `json:"detail"`
+}
+
+// GitServiceStatusError is returned when git-service responds with a non-200
+// status. Msg is the error message git-service reported, if any.
+type GitServiceStatusError struct {
+	StatusCode int
+	Msg        string
+}
+
+func newGitServiceStatusError(statusCode int, body []byte) *GitServiceStatusError {
+	statusErr := &GitServiceStatusError{StatusCode: statusCode}
+	var problem gitServiceProblem
+	var errResp gitServiceError
+	if jsonErr := json.Unmarshal(body, &problem); jsonErr == nil && problem.Detail != "" {
+		statusErr.Msg = problem.Detail
+	} else if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil {
+		statusErr.Msg = errResp.Msg
+	}
+	return statusErr
+}
+
+func (e *GitServiceStatusError) Error() string {
+	if e.Msg != "" {
+		return fmt.Sprintf("git-service error (%d): %s", e.StatusCode, e.Msg)
+	}
+	return fmt.Sprintf("git-service returned status %d", e.StatusCode)
+}
+
+// HTTPStatus returns the status code git-service responded with.
+func (e *GitServiceStatusError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// Detail returns the message git-service reported, falling back to the status text.
+func (e *GitServiceStatusError) Detail() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return strings.ToLower(http.StatusText(e.StatusCode))
+}
+
+// setHeaders authenticates the request and forwards the platform request id
+// so git-service errors can be correlated with the API request.
+func (c *GitService) setHeaders(ctx context.Context, req *http.Request) {
+	if c.pskToken != "" {
+		req.Header.Set("X-PSK-Token", c.pskToken)
+	}
+	if requestID := request_id.GetReqID(ctx); requestID != "" {
+		req.Header.Set(requestIDHeader, requestID)
+	}
+}
+
 type GitServiceQuickstartEntry struct {
 	Name        string `json:"name"`
 	DisplayName string `json:"displayName"`
@@ -74,16 +137,35 @@ type GitServiceQuickstartContentResponse struct {
 	Files []GitServiceFile `json:"files"`
 }
 
+// Ping checks that git-service is up by calling its health endpoint.
+func (c *GitService) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/test", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(ctx, req)
+
+	resp, err := c.do("ping", req)
+	if err != nil {
+		return fmt.Errorf("git-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode != http.StatusOK {
+		return &GitServiceStatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
 func (c *GitService) ListQuickstarts(ctx context.Context) (*GitServiceListQuickstartsResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/list-quickstarts", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	if c.pskToken != "" {
-		req.Header.Set("X-PSK-Token", c.pskToken)
-	}
+	c.setHeaders(ctx, req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do("list_quickstarts", req)
 	if err != nil {
 		return nil, fmt.Errorf("git-service request failed: %w", err)
 	}
@@ -100,11 +182,7 @@ func (c *GitService) ListQuickstarts(ctx context.Context) (*GitServiceListQuicks
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp gitServiceError
-		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil && errResp.Msg != "" {
-			return nil, fmt.Errorf("git-service error (%d): %s", resp.StatusCode, errResp.Msg)
-		}
-		return nil, fmt.Errorf("git-service returned status %d", resp.StatusCode)
+		return nil, newGitServiceStatusError(resp.StatusCode, body)
 	}
 
 	var result GitServiceListQuickstartsResponse
@@ -119,11 +197,9 @@ func (c *GitService) GetQuickstartContent(ctx context.Context, name string) (*Gi
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	if c.pskToken != "" {
-		req.Header.Set("X-PSK-Token", c.pskToken)
-	}
+	c.setHeaders(ctx, req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do("get_quickstart_content", req)
 	if err != nil {
 		return nil, fmt.Errorf("git-service request failed: %w", err)
 	}
@@ -140,11 +216,7 @@ func (c *GitService) GetQuickstartContent(ctx context.Context, name string) (*Gi
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp gitServiceError
-		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil && errResp.Msg != "" {
-			return nil, fmt.Errorf("git-service error (%d): %s", resp.StatusCode, errResp.Msg)
-		}
-		return nil, fmt.Errorf("git-service returned status %d", resp.StatusCode)
+		return nil, newGitServiceStatusError(resp.StatusCode, body)
 	}
 
 	var result GitServiceQuickstartContentResponse
@@ -170,11 +242,9 @@ func (c *GitService) SubmitPR(ctx context.Context, files []GitServiceFile, metad
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	if c.pskToken != "" {
-		req.Header.Set("X-PSK-Token", c.pskToken)
-	}
+	c.setHeaders(ctx, req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do("submit_pr", req)
 	if err != nil {
 		return nil, fmt.Errorf("git-service request failed: %w", err)
 	}
@@ -191,11 +261,7 @@ func (c *GitService) SubmitPR(ctx context.Context, files []GitServiceFile, metad
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp gitServiceError
-		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil && errResp.Msg != "" {
-			return nil, fmt.Errorf("git-service error (%d): %s", resp.StatusCode, errResp.Msg)
-		}
-		return nil, fmt.Errorf("git-service returned status %d", resp.StatusCode)
+		return nil, newGitServiceStatusError(resp.StatusCode, body)
 	}
 
 	var result GitServiceResponse
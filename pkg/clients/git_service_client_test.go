@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,3 +180,20 @@ func TestGetQuickstartContent_NotFound(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "quickstart not found")
 }
+
+func TestGitServiceMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	require.Error(t, NewGitService(server.URL, "").Ping(context.Background()))
+	require.Error(t, NewGitService("http://localhost:1", "").Ping(context.Background()))
+
+	w := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	metrics := w.Body.String()
+	assert.Contains(t, metrics, `quickstarts_git_service_request_duration_seconds_count{code="502",operation="ping"} 1`)
+	assert.Contains(t, metrics, `quickstarts_git_service_request_errors_total{operation="ping",reason="status"} 1`)
+	assert.Contains(t, metrics, `quickstarts_git_service_request_errors_total{operation="ping",reason="transport"} 1`)
+}
@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	p "github.com/prometheus/client_golang/prometheus"
+	pa "github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	gitServiceRequestDuration = pa.NewHistogramVec(p.HistogramOpts{
+		Name:    "quickstarts_git_service_request_duration_seconds",
+		Help:    "Duration of git-service requests until response headers are received, by operation and status code",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"operation", "code"})
+	gitServiceRequestErrors = pa.NewCounterVec(p.CounterOpts{
+		Name: "quickstarts_git_service_request_errors_total",
+		Help: "Total number of failed git-service requests by operation and reason",
+	}, []string{"operation", "reason"})
+)
+
+// do sends req and records its latency and outcome under operation.
+// Transport failures are labeled with code "error"; responses other than
+// 200 also count as errors with reason "status".
+func (c *GitService) do(operation string, req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	elapsed := time.Since(start).Seconds()
+	if err != nil {
+		gitServiceRequestDuration.WithLabelValues(operation, "error").Observe(elapsed)
+		gitServiceRequestErrors.WithLabelValues(operation, transportErrorReason(err)).Inc()
+		return nil, err
+	}
+	gitServiceRequestDuration.WithLabelValues(operation, strconv.Itoa(resp.StatusCode)).Observe(elapsed)
+	if resp.StatusCode != http.StatusOK {
+		gitServiceRequestErrors.WithLabelValues(operation, "status").Inc()
+	}
+	return resp, nil
+}
+
+func transportErrorReason(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return "timeout"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	return "transport"
+}
@@ -0,0 +1,436 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ExportArchive writes the catalog from db to w, plus favorites and progress
+// when opts.UserData is set. On PostgreSQL the export reads from a single
+// repeatable-read snapshot, so a concurrent seed cannot produce a mixed archive.
+func ExportArchive(db *gorm.DB, w io.Writer, opts archive.Options) (archive.Counts, error) {
+	var counts archive.Counts
+	var txOpts []*sql.TxOptions
+	if db.Dialector.Name() == "postgres" {
+		txOpts = append(txOpts, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		marker, err := CurrentSeed(tx)
+		if err != nil {
+			return fmt.Errorf("load seed generation: %w", err)
+		}
+		aw, err := archive.NewWriter(w, archive.Header{
+			ExportedAt: time.Now().UTC(),
+			UserData:   opts.UserData,
+			Source:     marker.Source,
+			Revision:   marker.Revision,
+		})
+		if err != nil {
+			return err
+		}
+		write := func(rec archive.Record) error {
+			if err := aw.Write(rec); err != nil {
+				return fmt.Errorf("write %s record: %w", rec.Kind, err)
+			}
+			counts.Add(rec.Kind)
+			return nil
+		}
+
+		var tags []models.Tag
+		if err := tx.Order("type, value").Find(&tags).Error; err != nil {
+			return fmt.Errorf("load tags: %w", err)
+		}
+		for _, t := range tags {
+			if err := write(archive.Record{Kind: archive.KindTag, Tag: &archive.Tag{Type: t.Type, Value: t.Value}}); err != nil {
+				return err
+			}
+		}
+
+		var quickstarts []models.Quickstart
+		if err := tx.Preload("Tags").Order("name").Find(&quickstarts).Error; err != nil {
+			return fmt.Errorf("load quickstarts: %w", err)
+		}
+		for _, q := range quickstarts {
+			rec := archive.Quickstart{
+				Name:           q.Name,
+				Content:        json.RawMessage(q.Content),
+				Tags:           archiveTags(q.Tags),
+				SourceFile:     q.SourceFile,
+				SourceRevision: q.SourceRevision,
+				Lifecycle:      q.Lifecycle,
+			}
+			if err := write(archive.Record{Kind: archive.KindQuickstart, Quickstart: &rec}); err != nil {
+				return err
+			}
+		}
+
+		var helpTopics []models.HelpTopic
+		if err := tx.Preload("Tags").Order("name").Find(&helpTopics).Error; err != nil {
+			return fmt.Errorf("load help topics: %w", err)
+		}
+		for _, h := range helpTopics {
+			rec := archive.HelpTopic{
+				Name:      h.Name,
+				GroupName: h.GroupName,
+				Content:   json.RawMessage(h.Content),
+				Tags:      archiveTags(h.Tags),
+				Lifecycle: h.Lifecycle,
+			}
+			if err := write(archive.Record{Kind: archive.KindHelpTopic, HelpTopic: &rec}); err != nil {
+				return err
+			}
+		}
+
+		var aliases []models.QuickstartAlias
+		if err := tx.Order("name").Find(&aliases).Error; err != nil {
+			return fmt.Errorf("load quickstart aliases: %w", err)
+		}
+		for _, a := range aliases {
+			rec := archive.Alias{Name: a.Name, QuickstartName: a.QuickstartName}
+			if err := write(archive.Record{Kind: archive.KindAlias, Alias: &rec}); err != nil {
+				return err
+			}
+		}
+
+		if !opts.UserData {
+			return nil
+		}
+
+		var favorites []models.FavoriteQuickstart
+		if err := tx.Order("account_id, quickstart_name").Find(&favorites).Error; err != nil {
+			return fmt.Errorf("load favorites: %w", err)
+		}
+		for _, f := range favorites {
+			rec := archive.Favorite{AccountId: f.AccountId, QuickstartName: f.QuickstartName, Favorite: f.Favorite}
+			if err := write(archive.Record{Kind: archive.KindFavorite, Favorite: &rec}); err != nil {
+				return err
+			}
+		}
+
+		var progress []models.QuickstartProgress
+		if err := tx.Order("account_id, quickstart_name").Find(&progress).Error; err != nil {
+			return fmt.Errorf("load progress: %w", err)
+		}
+		for _, p := range progress {
+			rec := archive.Progress{AccountId: p.AccountId, QuickstartName: p.QuickstartName}
+			if p.Progress != nil {
+				rec.Progress = json.RawMessage(*p.Progress)
+			}
+			if err := write(archive.Record{Kind: archive.KindProgress, Progress: &rec}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, txOpts...)
+	return counts, err
+}
+
+// archiveTags converts tags to their archived form, sorted so exports of the
+// same content are identical.
+func archiveTags(tags []models.Tag) []archive.Tag {
+	result := make([]archive.Tag, len(tags))
+	for i, t := range tags {
+		result[i] = archive.Tag{Type: t.Type, Value: t.Value}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// ImportArchive loads the archive read from r into db in one transaction.
+// Records are matched to existing rows by name (and account for user data)
+// and updated in place, so importing the same archive twice leaves the
+// database unchanged. Rows the archive does not mention are kept. Favorites
+// and progress are only loaded when opts.UserData is set. Errors caused by
+// malformed archives wrap archive.ErrInvalid.
+func ImportArchive(db *gorm.DB, r io.Reader, opts archive.Options) (archive.Counts, error) {
+	var counts archive.Counts
+	ar, err := archive.NewReader(r)
+	if err != nil {
+		return counts, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		acquireAdvisoryLockIfSupported(tx)
+		im := &importer{tx: tx, tags: map[archive.Tag]models.Tag{}}
+
+		for n := 1; ; n++ {
+			rec, err := ar.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("record %d: %w", n, err)
+			}
+			if rec.Kind.UserData() && !opts.UserData {
+				continue
+			}
+
+			applied, err := im.apply(rec)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", n, err)
+			}
+			if applied {
+				counts.Add(rec.Kind)
+			} else {
+				counts.Skipped++
+			}
+		}
+		// The imported content keeps the provenance it was exported with.
+		return bumpSeedGeneration(tx, ar.Header.Source, ar.Header.Revision)
+	})
+	if err != nil {
+		return archive.Counts{}, err
+	}
+
+	slog.Info("Imported content archive",
+		"exported_at", ar.Header.ExportedAt,
+		"tags", counts.Tags,
+		"quickstarts", counts.Quickstarts,
+		"help_topics", counts.HelpTopics,
+		"aliases", counts.Aliases,
+		"favorites", counts.Favorites,
+		"progress", counts.Progress,
+		"skipped", counts.Skipped)
+	return counts, nil
+}
+
+// importer upserts archive records inside an import transaction.
+type importer struct {
+	tx   *gorm.DB
+	tags map[archive.Tag]models.Tag
+}
+
+// apply stores rec and reports whether it was applied rather than skipped.
+func (im *importer) apply(rec archive.Record) (bool, error) {
+	switch rec.Kind {
+	case archive.KindTag:
+		_, err := im.tag(*rec.Tag)
+		return err == nil, err
+	case archive.KindQuickstart:
+		return true, im.quickstart(*rec.Quickstart)
+	case archive.KindHelpTopic:
+		return true, im.helpTopic(*rec.HelpTopic)
+	case archive.KindAlias:
+		return im.alias(*rec.Alias)
+	case archive.KindFavorite:
+		return im.favorite(*rec.Favorite)
+	case archive.KindProgress:
+		return true, im.progress(*rec.Progress)
+	}
+	return false, nil
+}
+
+func (im *importer) tag(t archive.Tag) (models.Tag, error) {
+	if tag, ok := im.tags[t]; ok {
+		return tag, nil
+	}
+	var tag models.Tag
+	r := im.tx.Where("type = ? AND value = ?", t.Type, t.Value).Limit(1).Find(&tag)
+	if r.Error != nil {
+		return tag, fmt.Errorf("find tag %s/%s: %w", t.Type, t.Value, r.Error)
+	}
+	if r.RowsAffected == 0 {
+		tag = models.Tag{Type: t.Type, Value: t.Value}
+		if err := im.tx.Create(&tag).Error; err != nil {
+			return tag, fmt.Errorf("create tag %s/%s: %w", t.Type, t.Value, err)
+		}
+	}
+	im.tags[t] = tag
+	return tag, nil
+}
+
+func (im *importer) resolveTags(tags []archive.Tag) ([]models.Tag, error) {
+	result := make([]models.Tag, len(tags))
+	for i, t := range tags {
+		tag, err := im.tag(t)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = tag
+	}
+	return result, nil
+}
+
+func (im *importer) quickstart(rec archive.Quickstart) error {
+	tags, err := im.resolveTags(rec.Tags)
+	if err != nil {
+		return err
+	}
+	// Soft-deleted rows still hold the unique name, so they are revived.
+	var q models.Quickstart
+	if err := im.tx.Unscoped().Where("name = ?", rec.Name).Limit(1).Find(&q).Error; err != nil {
+		return fmt.Errorf("find quickstart %s: %w", rec.Name, err)
+	}
+	q.Name = rec.Name
+	q.Content = datatypes.JSON(rec.Content)
+	q.SourceFile = rec.SourceFile
+	q.SourceRevision = rec.SourceRevision
+	q.Lifecycle = importedLifecycle(rec.Lifecycle)
+	q.DeletedAt = gorm.DeletedAt{}
+	if q.ID == 0 {
+		q.Tags = tags
+		if err := im.tx.Create(&q).Error; err != nil {
+			return fmt.Errorf("create quickstart %s: %w", rec.Name, err)
+		}
+		return nil
+	}
+	if err := im.tx.Unscoped().Omit("Tags").Save(&q).Error; err != nil {
+		return fmt.Errorf("update quickstart %s: %w", rec.Name, err)
+	}
+	if err := im.tx.Model(&q).Association("Tags").Replace(tags); err != nil {
+		return fmt.Errorf("replace quickstart %s tags: %w", rec.Name, err)
+	}
+	return nil
+}
+
+func (im *importer) helpTopic(rec archive.HelpTopic) error {
+	tags, err := im.resolveTags(rec.Tags)
+	if err != nil {
+		return err
+	}
+	var h models.HelpTopic
+	if err := im.tx.Unscoped().Where("name = ?", rec.Name).Limit(1).Find(&h).Error; err != nil {
+		return fmt.Errorf("find help topic %s: %w", rec.Name, err)
+	}
+	h.Name = rec.Name
+	h.GroupName = rec.GroupName
+	h.Content = datatypes.JSON(rec.Content)
+	h.Lifecycle = importedLifecycle(rec.Lifecycle)
+	h.DeletedAt = gorm.DeletedAt{}
+	if h.ID == 0 {
+		h.Tags = tags
+		if err := im.tx.Create(&h).Error; err != nil {
+			return fmt.Errorf("create help topic %s: %w", rec.Name, err)
+		}
+		return nil
+	}
+	if err := im.tx.Unscoped().Omit("Tags").Save(&h).Error; err != nil {
+		return fmt.Errorf("update help topic %s: %w", rec.Name, err)
+	}
+	if err := im.tx.Model(&h).Association("Tags").Replace(tags); err != nil {
+		return fmt.Errorf("replace help topic %s tags: %w", rec.Name, err)
+	}
+	return nil
+}
+
+// importedLifecycle returns the lifecycle to store for an archived item.
+// Version 1 archives have none, and their items were published.
+func importedLifecycle(l models.Lifecycle) models.Lifecycle {
+	if l.Status == "" {
+		l.Status = models.StatusPublished
+	}
+	return l
+}
+
+// alias stores rec, skipping aliases of quickstarts that do not exist.
+func (im *importer) alias(rec archive.Alias) (bool, error) {
+	var count int64
+	if err := im.tx.Model(&models.Quickstart{}).Where("name = ?", rec.QuickstartName).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("find quickstart %s: %w", rec.QuickstartName, err)
+	}
+	if count == 0 {
+		return false, nil
+	}
+	alias := models.QuickstartAlias{Name: rec.Name, QuickstartName: rec.QuickstartName}
+	if err := im.tx.Save(&alias).Error; err != nil {
+		return false, fmt.Errorf("store alias %s: %w", rec.Name, err)
+	}
+	return true, nil
+}
+
+// currentName returns the current name of the quickstart previously called
+// name, or name itself. Aliases come before user data in an archive, so
+// favorites and progress recorded under a previous name follow the rename,
+// as they do when seeding.
+func (im *importer) currentName(name string) (string, error) {
+	var alias models.QuickstartAlias
+	r := im.tx.Where("name = ?", name).Limit(1).Find(&alias)
+	if r.Error != nil {
+		return "", fmt.Errorf("find alias %s: %w", name, r.Error)
+	}
+	if r.RowsAffected == 0 {
+		return name, nil
+	}
+	return alias.QuickstartName, nil
+}
+
+// favorite stores rec, skipping favorites of quickstarts that do not exist.
+func (im *importer) favorite(rec archive.Favorite) (bool, error) {
+	name, err := im.currentName(rec.QuickstartName)
+	if err != nil {
+		return false, err
+	}
+	rec.QuickstartName = name
+	var count int64
+	if err := im.tx.Model(&models.Quickstart{}).Where("name = ?", rec.QuickstartName).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("find quickstart %s: %w", rec.QuickstartName, err)
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	var f models.FavoriteQuickstart
+	r := im.tx.Where("account_id = ? AND quickstart_name = ?", rec.AccountId, rec.QuickstartName).Limit(1).Find(&f)
+	if r.Error != nil {
+		return false, fmt.Errorf("find favorite %s: %w", rec.QuickstartName, r.Error)
+	}
+	if r.RowsAffected == 0 {
+		f = models.FavoriteQuickstart{AccountId: rec.AccountId, QuickstartName: rec.QuickstartName, Favorite: rec.Favorite}
+		if err := im.tx.Create(&f).Error; err != nil {
+			return false, fmt.Errorf("create favorite %s: %w", rec.QuickstartName, err)
+		}
+		return true, nil
+	}
+	if err := im.tx.Model(&f).Update("favorite", rec.Favorite).Error; err != nil {
+		return false, fmt.Errorf("update favorite %s: %w", rec.QuickstartName, err)
+	}
+	return true, nil
+}
+
+func (im *importer) progress(rec archive.Progress) error {
+	name, err := im.currentName(rec.QuickstartName)
+	if err != nil {
+		return err
+	}
+	rec.QuickstartName = name
+	// The progress_session unique index covers soft-deleted rows too.
+	var p models.QuickstartProgress
+	if err := im.tx.Unscoped().Where("account_id = ? AND quickstart_name = ?", rec.AccountId, rec.QuickstartName).Limit(1).Find(&p).Error; err != nil {
+		return fmt.Errorf("find progress %s: %w", rec.QuickstartName, err)
+	}
+	p.AccountId = rec.AccountId
+	p.QuickstartName = rec.QuickstartName
+	p.Progress = nil
+	if len(rec.Progress) > 0 {
+		data := datatypes.JSON(rec.Progress)
+		p.Progress = &data
+	}
+	p.DeletedAt = gorm.DeletedAt{}
+	if p.ID == 0 {
+		if err := im.tx.Create(&p).Error; err != nil {
+			return fmt.Errorf("create progress %s: %w", rec.QuickstartName, err)
+		}
+		return nil
+	}
+	if err := im.tx.Unscoped().Save(&p).Error; err != nil {
+		return fmt.Errorf("update progress %s: %w", rec.QuickstartName, err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// openArchiveDB returns an empty database, so archive tests do not depend on
+// the content other tests seed into DB.
+func openArchiveDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, MigrateTestSchema(db))
+	return db
+}
+
+func TestArchiveExportImport(t *testing.T) {
+	source := openArchiveDB(t, "source.db")
+	rhel := models.Tag{Type: models.BundleTag, Value: "rhel"}
+	iam := models.Tag{Type: models.ProductFamilies, Value: "iam"}
+	require.NoError(t, source.Create(&[]*models.Tag{&rhel, &iam}).Error)
+	require.NoError(t, source.Create(&models.Quickstart{
+		Name:           "first",
+		Content:        []byte(`{"spec":{"displayName":"First"}}`),
+		Tags:           []models.Tag{rhel, iam},
+		SourceFile:     "quickstarts/first/first.yml",
+		SourceRevision: "abc123",
+		Lifecycle:      models.Lifecycle{Status: models.StatusDeprecated, Replacement: "second"},
+	}).Error)
+	publishAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, source.Create(&models.HelpTopic{
+		Name:      "topic",
+		GroupName: "group",
+		Content:   []byte(`{"title":"T"}`),
+		Tags:      []models.Tag{{Type: models.BundleTag, Value: "settings"}},
+		Lifecycle: models.Lifecycle{Status: models.StatusDraft, PublishAt: &publishAt},
+	}).Error)
+	require.NoError(t, source.Create(&models.QuickstartAlias{Name: "old-first", QuickstartName: "first"}).Error)
+	require.NoError(t, source.Create(&models.FavoriteQuickstart{AccountId: "123", QuickstartName: "first", Favorite: true}).Error)
+	progress := datatypes.JSON(`{"step":2}`)
+	require.NoError(t, source.Create(&models.QuickstartProgress{AccountId: 123, QuickstartName: "first", Progress: &progress}).Error)
+
+	var withUserData bytes.Buffer
+	counts, err := ExportArchive(source, &withUserData, archive.Options{UserData: true})
+	require.NoError(t, err)
+	assert.Equal(t, archive.Counts{Tags: 3, Quickstarts: 1, HelpTopics: 1, Aliases: 1, Favorites: 1, Progress: 1}, counts)
+
+	t.Run("catalog only export", func(t *testing.T) {
+		var buf bytes.Buffer
+		counts, err := ExportArchive(source, &buf, archive.Options{})
+		require.NoError(t, err)
+		assert.Zero(t, counts.Favorites)
+		assert.NotContains(t, buf.String(), `"kind":"favorite"`)
+		assert.NotContains(t, buf.String(), `"kind":"progress"`)
+	})
+
+	t.Run("import is idempotent", func(t *testing.T) {
+		target := openArchiveDB(t, "target.db")
+		for i := 0; i < 2; i++ {
+			counts, err := ImportArchive(target, bytes.NewReader(withUserData.Bytes()), archive.Options{UserData: true})
+			require.NoError(t, err)
+			assert.Equal(t, archive.Counts{Tags: 3, Quickstarts: 1, HelpTopics: 1, Aliases: 1, Favorites: 1, Progress: 1}, counts)
+		}
+
+		var rows int64
+		target.Model(&models.Quickstart{}).Count(&rows)
+		assert.EqualValues(t, 1, rows)
+		target.Model(&models.Tag{}).Count(&rows)
+		assert.EqualValues(t, 3, rows)
+		target.Model(&models.FavoriteQuickstart{}).Count(&rows)
+		assert.EqualValues(t, 1, rows)
+		target.Model(&models.QuickstartProgress{}).Count(&rows)
+		assert.EqualValues(t, 1, rows)
+
+		var q models.Quickstart
+		require.NoError(t, target.Preload("Tags").Where("name = ?", "first").First(&q).Error)
+		assert.Len(t, q.Tags, 2)
+		assert.JSONEq(t, `{"spec":{"displayName":"First"}}`, string(q.Content))
+		assert.Equal(t, "quickstarts/first/first.yml", q.SourceFile)
+		assert.Equal(t, "abc123", q.SourceRevision)
+		assert.Equal(t, models.Lifecycle{Status: models.StatusDeprecated, Replacement: "second"}, q.Lifecycle)
+
+		var h models.HelpTopic
+		require.NoError(t, target.Where("name = ?", "topic").First(&h).Error)
+		assert.Equal(t, models.StatusDraft, h.Status, "drafts stay drafts")
+		require.NotNil(t, h.PublishAt)
+		assert.True(t, publishAt.Equal(*h.PublishAt))
+
+		var alias models.QuickstartAlias
+		require.NoError(t, target.Where("name = ?", "old-first").First(&alias).Error)
+		assert.Equal(t, "first", alias.QuickstartName)
+
+		generation, err := CurrentSeedGeneration(target)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, generation, "each import makes content caches reload")
+
+		// A round trip reproduces the source archive apart from the timestamp.
+		var again bytes.Buffer
+		_, err = ExportArchive(target, &again, archive.Options{UserData: true})
+		require.NoError(t, err)
+		assert.Equal(t, withoutHeader(withUserData.String()), withoutHeader(again.String()))
+	})
+
+	t.Run("import updates existing rows", func(t *testing.T) {
+		target := openArchiveDB(t, "update.db")
+		stale := datatypes.JSON(`{"step":1}`)
+		require.NoError(t, target.Create(&models.Quickstart{Name: "first", Content: []byte(`{"old":true}`), Tags: []models.Tag{{Type: models.BundleTag, Value: "old"}}}).Error)
+		require.NoError(t, target.Create(&models.QuickstartProgress{AccountId: 123, QuickstartName: "first", Progress: &stale}).Error)
+		require.NoError(t, target.Create(&models.Quickstart{Name: "local-only", Content: []byte(`{}`)}).Error)
+
+		_, err := ImportArchive(target, bytes.NewReader(withUserData.Bytes()), archive.Options{UserData: true})
+		require.NoError(t, err)
+
+		var q models.Quickstart
+		require.NoError(t, target.Preload("Tags").Where("name = ?", "first").First(&q).Error)
+		assert.JSONEq(t, `{"spec":{"displayName":"First"}}`, string(q.Content))
+		values := []string{}
+		for _, tag := range q.Tags {
+			values = append(values, tag.Value)
+		}
+		assert.ElementsMatch(t, []string{"rhel", "iam"}, values)
+
+		var p models.QuickstartProgress
+		require.NoError(t, target.Where("account_id = ?", 123).First(&p).Error)
+		assert.JSONEq(t, `{"step":2}`, string(*p.Progress))
+
+		var rows int64
+		target.Model(&models.Quickstart{}).Where("name = ?", "local-only").Count(&rows)
+		assert.EqualValues(t, 1, rows, "rows missing from the archive are kept")
+	})
+
+	t.Run("user data can be left out", func(t *testing.T) {
+		target := openArchiveDB(t, "nouser.db")
+		counts, err := ImportArchive(target, bytes.NewReader(withUserData.Bytes()), archive.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, counts.Quickstarts)
+		assert.Zero(t, counts.Favorites)
+
+		var rows int64
+		target.Model(&models.FavoriteQuickstart{}).Count(&rows)
+		assert.Zero(t, rows)
+	})
+
+	t.Run("favorites of missing quickstarts are skipped", func(t *testing.T) {
+		target := openArchiveDB(t, "skip.db")
+		input := `{"kind":"header","header":{"version":1}}
+{"kind":"favorite","favorite":{"accountId":"1","quickstartName":"missing","favorite":true}}
+`
+		counts, err := ImportArchive(target, strings.NewReader(input), archive.Options{UserData: true})
+		require.NoError(t, err)
+		assert.Equal(t, archive.Counts{Skipped: 1}, counts)
+	})
+
+	t.Run("user data follows renames", func(t *testing.T) {
+		target := openArchiveDB(t, "renamed.db")
+		input := `{"kind":"header","header":{"version":2}}
+{"kind":"quickstart","quickstart":{"name":"first","content":{},"status":"published"}}
+{"kind":"alias","alias":{"name":"old-first","quickstartName":"first"}}
+{"kind":"alias","alias":{"name":"old-missing","quickstartName":"missing"}}
+{"kind":"favorite","favorite":{"accountId":"1","quickstartName":"old-first","favorite":true}}
+{"kind":"progress","progress":{"accountId":1,"quickstartName":"old-first","progress":{"step":1}}}
+`
+		counts, err := ImportArchive(target, strings.NewReader(input), archive.Options{UserData: true})
+		require.NoError(t, err)
+		assert.Equal(t, archive.Counts{Quickstarts: 1, Aliases: 1, Favorites: 1, Progress: 1, Skipped: 1}, counts)
+
+		var rows int64
+		target.Model(&models.FavoriteQuickstart{}).Where("quickstart_name = ?", "first").Count(&rows)
+		assert.EqualValues(t, 1, rows)
+		target.Model(&models.QuickstartProgress{}).Where("quickstart_name = ?", "first").Count(&rows)
+		assert.EqualValues(t, 1, rows)
+	})
+
+	t.Run("items of version 1 archives are published", func(t *testing.T) {
+		target := openArchiveDB(t, "v1.db")
+		input := `{"kind":"header","header":{"version":1}}
+{"kind":"quickstart","quickstart":{"name":"first","content":{}}}
+`
+		_, err := ImportArchive(target, strings.NewReader(input), archive.Options{})
+		require.NoError(t, err)
+
+		var q models.Quickstart
+		require.NoError(t, target.Where("name = ?", "first").First(&q).Error)
+		assert.Equal(t, models.StatusPublished, q.Status)
+	})
+
+	t.Run("invalid archive changes nothing", func(t *testing.T) {
+		target := openArchiveDB(t, "invalid.db")
+		input := `{"kind":"header","header":{"version":1}}
+{"kind":"quickstart","quickstart":{"name":"new","content":{}}}
+{"kind":"quickstart","quickstart":{}}
+`
+		_, err := ImportArchive(target, strings.NewReader(input), archive.Options{})
+		require.ErrorIs(t, err, archive.ErrInvalid)
+		assert.Contains(t, err.Error(), "record 2")
+
+		var rows int64
+		target.Model(&models.Quickstart{}).Count(&rows)
+		assert.Zero(t, rows)
+	})
+}
+
+func withoutHeader(archive string) string {
+	_, records, _ := strings.Cut(archive, "\n")
+	return records
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/git-service/git"
+)
+
+// ContentSource is a directory of quickstart and help-topic content together
+// with where it came from.
+type ContentSource struct {
+	Dir      string // Directory holding the quickstarts/ and help-topics/ folders
+	Source   string // Content directory, repository URL or archive path
+	Revision string // Commit SHA or archive digest; empty when unknown
+	root     string
+	tmpDir   string
+}
+
+// File returns path relative to the root of the repository, archive or
+// content directory, with forward slashes.
+func (s *ContentSource) File(path string) string {
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Close removes the checkout or extracted archive, if any.
+func (s *ContentSource) Close() {
+	if s.tmpDir != "" {
+		os.RemoveAll(s.tmpDir)
+	}
+}
+
+// OpenContentSource prepares the content to seed. CONTENT_GIT_URL takes
+// precedence over CONTENT_ARCHIVE, which takes precedence over the local
+// content directory. Repositories and archives are unpacked into a temporary
+// directory and CONTENT_SOURCE_PATH selects the content folder inside them.
+func OpenContentSource() (*ContentSource, error) {
+	cfg := config.Get()
+	switch {
+	case cfg.ContentGitURL != "":
+		return OpenGitContent(cfg.ContentGitURL, cfg.ContentGitRef, cfg.ContentGitToken, cfg.ContentSourcePath)
+	case cfg.ContentArchive != "":
+		return openArchiveContent(cfg.ContentArchive, cfg.ContentSourcePath)
+	}
+
+	dir := contentDir()
+	src := &ContentSource{Dir: dir, Source: dir, root: dir}
+	// Local checkouts report their commit; image builds ship docs without
+	// git metadata.
+	if sha, err := git.HeadRevision(dir); err == nil {
+		src.Revision = sha
+	}
+	return src, nil
+}
+
+// OpenGitContent checks out ref of the repository at url into a temporary
+// directory and returns the content folder at path inside it. url may be a
+// local path.
+func OpenGitContent(url, ref, token, path string) (*ContentSource, error) {
+	tmp, err := os.MkdirTemp("", "quickstarts-content-")
+	if err != nil {
+		return nil, err
+	}
+	src := &ContentSource{Source: url, root: filepath.Join(tmp, "repo"), tmpDir: tmp}
+	sha, err := git.CheckoutRef(url, ref, token, src.root)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	src.Revision = sha
+	if src.Dir, err = contentSubdir(src.root, path); err != nil {
+		src.Close()
+		return nil, err
+	}
+	return src, nil
+}
+
+func openArchiveContent(archivePath, path string) (*ContentSource, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tmp, err := os.MkdirTemp("", "quickstarts-content-")
+	if err != nil {
+		return nil, err
+	}
+	src := &ContentSource{Source: archivePath, root: tmp, tmpDir: tmp}
+	digest, err := extractTar(f, tmp)
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("extract %s: %w", archivePath, err)
+	}
+	src.Revision = "sha256:" + digest
+	if src.Dir, err = contentSubdir(tmp, path); err != nil {
+		src.Close()
+		return nil, err
+	}
+	return src, nil
+}
+
+// contentSubdir returns path inside root, refusing paths that escape it.
+func contentSubdir(root, path string) (string, error) {
+	dir := filepath.Join(root, filepath.Clean("/"+path))
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("content path %q: %w", path, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("content path %q is not a directory", path)
+	}
+	return dir, nil
+}
+
+// extractTar unpacks a tar or gzip-compressed tar stream into dir and returns
+// the SHA-256 digest of the stream as read. Only regular files and
+// directories are extracted, and entries escaping dir are rejected.
+func extractTar(r io.Reader, dir string) (string, error) {
+	hash := sha256.New()
+	br := bufio.NewReader(io.TeeReader(r, hash))
+
+	var stream io.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+		stream = gz
+	}
+
+	tr := tar.NewReader(stream)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		name := filepath.Clean(hdr.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("entry %q escapes the archive", hdr.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := writeTarFile(tr, target); err != nil {
+				return "", err
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			// Links could point outside dir; content never needs them.
+			slog.Warn("Skipping link in content archive", "name", hdr.Name)
+		default:
+			// PAX headers and other metadata entries carry no content.
+			slog.Debug("Skipping archive entry", "name", hdr.Name, "type", hdr.Typeflag)
+		}
+	}
+
+	// Hash whatever trails the last entry, so the digest covers the file.
+	if _, err := io.Copy(io.Discard, br); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeTarFile(r io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestTar(t *testing.T, gzipped bool, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(&buf)
+	if gzipped {
+		tw = tar.NewWriter(gz)
+	}
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if gzipped {
+		require.NoError(t, gz.Close())
+	}
+	path := filepath.Join(t.TempDir(), "content.tar")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+	return path
+}
+
+func TestOpenArchiveContent(t *testing.T) {
+	for _, gzipped := range []bool{false, true} {
+		path := writeTestTar(t, gzipped, map[string]string{"docs/quickstarts/demo/metadata.yml": "kind: QuickStarts\nname: demo\n"})
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		sum := sha256.Sum256(data)
+
+		src, err := openArchiveContent(path, "docs")
+		require.NoError(t, err)
+		assert.Equal(t, path, src.Source)
+		assert.Equal(t, "sha256:"+hex.EncodeToString(sum[:]), src.Revision)
+		_, err = os.Stat(filepath.Join(src.Dir, "quickstarts", "demo", "metadata.yml"))
+		assert.NoError(t, err)
+
+		src.Close()
+		_, err = os.Stat(src.Dir)
+		assert.True(t, os.IsNotExist(err), "Close removes the extracted files")
+	}
+
+	t.Run("rejects entries outside the archive", func(t *testing.T) {
+		path := writeTestTar(t, false, map[string]string{"../escape.yml": "x"})
+		_, err := openArchiveContent(path, "docs")
+		assert.ErrorContains(t, err, "escapes the archive")
+	})
+
+	t.Run("rejects a missing content path", func(t *testing.T) {
+		path := writeTestTar(t, false, map[string]string{"other/file.yml": "x"})
+		_, err := openArchiveContent(path, "docs")
+		assert.ErrorContains(t, err, `content path "docs"`)
+	})
+}
+
+func TestOpenGitContent(t *testing.T) {
+	work := t.TempDir()
+	repo, err := gogit.PlainInit(work, false)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(work, "docs", "help-topics"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "docs", "help-topics", "README.md"), []byte("topics"), 0o644))
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("docs")
+	require.NoError(t, err)
+	sha, err := w.Commit("add docs", &gogit.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()}})
+	require.NoError(t, err)
+
+	cfg := config.Get()
+	previous := *cfg
+	t.Cleanup(func() { *cfg = previous })
+	cfg.ContentGitURL = work
+	cfg.ContentGitRef = sha.String()
+	cfg.ContentSourcePath = "docs"
+
+	src, err := OpenContentSource()
+	require.NoError(t, err)
+	defer src.Close()
+	assert.Equal(t, work, src.Source)
+	assert.Equal(t, sha.String(), src.Revision)
+	_, err = os.Stat(filepath.Join(src.Dir, "help-topics", "README.md"))
+	assert.NoError(t, err)
+}
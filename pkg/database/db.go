@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/RedHatInsights/quickstarts/config"
-	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
@@ -27,7 +26,7 @@ func Init() {
 
 	var dbdns string
 	if cfg.Test && cfg.TestDatabaseURL != "" {
-		dia = postgres.Open(cfg.TestDatabaseURL)
+		dia = postgres.Open(withStatementTimeout(cfg.TestDatabaseURL, cfg.DbStatementTimeout))
 	} else if cfg.Test {
 		dia = sqlite.Open(cfg.DbName)
 	} else {
@@ -36,7 +35,7 @@ func Init() {
 			dbdns = fmt.Sprintf("%s  sslrootcert=%s", dbdns, cfg.DbSSLRootCert)
 		}
 
-		dia = postgres.Open(dbdns)
+		dia = postgres.Open(withStatementTimeout(dbdns, cfg.DbStatementTimeout))
 	}
 
 	DB, err = gorm.Open(dia, &gorm.Config{})
@@ -44,6 +43,18 @@ func Init() {
 	if err != nil {
 		panic(fmt.Sprintf("failed to connect database: %s", err.Error()))
 	}
+	if sqlDB, err := DB.DB(); err != nil {
+		logrus.Warnf("Failed to configure the connection pool: %s", err.Error())
+	} else {
+		configurePool(sqlDB, cfg)
+		registerPoolMetrics("primary", sqlDB)
+	}
+	if err := registerMetricsCallbacks(DB); err != nil {
+		logrus.Warnf("Failed to register database metrics: %s", err.Error())
+	}
+	if err := registerTracingCallbacks(DB); err != nil {
+		logrus.Warnf("Failed to register database tracing: %s", err.Error())
+	}
 
 	// Enable fuzzystrmatch extension for Levenshtein distance fuzzy search.
 	// Use the actual database dialect to decide — this correctly handles
@@ -60,43 +71,22 @@ func Init() {
 			isFuzzySearchSupported = true
 		}
 	} else {
-		// Check if fuzzystrmatch extension is already installed to avoid issuing DDL.
-		// CREATE EXTENSION IF NOT EXISTS still executes DDL internally, which breaks
+		// The extension is installed by the 0002_fuzzystrmatch migration. Only
+		// check for it here: the API must not issue DDL, which breaks the
 		// PostgreSQL logical replication used by RDS blue/green deployments.
 		var count int64
 		if err := DB.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'fuzzystrmatch'").Scan(&count).Error; err != nil {
 			logrus.Warnf("Failed to check fuzzystrmatch extension status: %s", err.Error())
 			isFuzzySearchSupported = false
 		} else if count > 0 {
-			logrus.Info("Fuzzystrmatch extension already installed")
+			logrus.Info("Fuzzystrmatch extension installed")
 			isFuzzySearchSupported = true
 		} else {
-			if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS fuzzystrmatch").Error; err != nil {
-				logrus.Warnf("Failed to enable fuzzystrmatch extension: %s", err.Error())
-				isFuzzySearchSupported = false
-			} else {
-				logrus.Info("Fuzzystrmatch extension enabled for fuzzy search")
-				isFuzzySearchSupported = true
-			}
+			logrus.Warn("Fuzzystrmatch extension is not installed; run the migrate job. Fuzzy search will fall back to ILIKE")
+			isFuzzySearchSupported = false
 		}
 	}
 
-	if !DB.Migrator().HasTable(&models.Quickstart{}) {
-		DB.Migrator().CreateTable(&models.Quickstart{})
-	}
-	if !DB.Migrator().HasTable(&models.Tag{}) {
-		DB.Migrator().CreateTable(&models.Tag{})
-	}
-	if !DB.Migrator().HasTable(&models.HelpTopic{}) {
-		DB.Migrator().CreateTable(&models.HelpTopic{})
-	}
-	if !DB.Migrator().HasTable(&models.FavoriteQuickstart{}) {
-		DB.Migrator().CreateTable(&models.FavoriteQuickstart{})
-	}
-	if !DB.Migrator().HasTable(&models.QuickstartProgress{}) {
-		DB.Migrator().CreateTable(&models.QuickstartProgress{})
-	}
-
 	logrus.Infoln("Database connection established")
 }
 
@@ -9,7 +9,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/ghodss/yaml"
 	"gorm.io/gorm"
@@ -20,11 +24,61 @@ type TagTemplate struct {
 	Value string `json:"value"`
 }
 
+type DeprecationTemplate struct {
+	Replacement string `yaml:"replacement"`
+}
+
 type MetadataTemplate struct {
-	Kind        string        `yaml:"kind"`
-	Name        string        `yaml:"name"`
-	Tags        []TagTemplate `yaml:"tags"`
-	ContentPath string
+	Kind          string              `yaml:"kind"`
+	Name          string              `yaml:"name"`
+	Tags          []TagTemplate       `yaml:"tags"`
+	PreviousNames []string            `yaml:"previousNames"`
+	Status        string              `yaml:"status"`
+	PublishAt     string              `yaml:"publishAt"`
+	UnpublishAt   string              `yaml:"unpublishAt"`
+	Deprecation   DeprecationTemplate `yaml:"deprecation"`
+	ContentPath   string
+}
+
+// Lifecycle returns the publishing state set by the status, publishAt,
+// unpublishAt and deprecation fields. Dates are RFC 3339 timestamps or plain
+// dates, which mean midnight UTC.
+func (t MetadataTemplate) Lifecycle() (models.Lifecycle, error) {
+	l := models.Lifecycle{Status: t.Status, Replacement: t.Deprecation.Replacement}
+	switch l.Status {
+	case "":
+		l.Status = models.StatusPublished
+	case models.StatusDraft, models.StatusPublished, models.StatusDeprecated:
+	default:
+		return l, fmt.Errorf("unknown status %q, expected draft, published or deprecated", t.Status)
+	}
+	if l.Replacement != "" && l.Status != models.StatusDeprecated {
+		return l, fmt.Errorf("deprecation.replacement is set but status is %q, not deprecated", l.Status)
+	}
+	var err error
+	if l.PublishAt, err = parseLifecycleTime("publishAt", t.PublishAt); err != nil {
+		return l, err
+	}
+	if l.UnpublishAt, err = parseLifecycleTime("unpublishAt", t.UnpublishAt); err != nil {
+		return l, err
+	}
+	if l.PublishAt != nil && l.UnpublishAt != nil && !l.UnpublishAt.After(*l.PublishAt) {
+		return l, fmt.Errorf("unpublishAt %s is not after publishAt %s", t.UnpublishAt, t.PublishAt)
+	}
+	return l, nil
+}
+
+func parseLifecycleTime(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			parsed = parsed.UTC()
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %q is not a date or an RFC 3339 timestamp", field, value)
 }
 
 func readMetadata(loc string) (MetadataTemplate, error) {
@@ -58,9 +112,11 @@ func contentDir() string {
 	return "docs"
 }
 
-func findTags() []MetadataTemplate {
+// findTags reads every quickstart and help topic metadata file under base. It
+// returns the templates it could read and one error per file it could not.
+func findTags(base string) ([]MetadataTemplate, []models.SeedItemError) {
 	var MetadataTemplates []MetadataTemplate
-	base := contentDir()
+	var itemErrors []models.SeedItemError
 	quickstartsFiles, err := filepath.Glob(filepath.Join(base, "quickstarts", "*", "metadata.y*"))
 	if err != nil {
 		slog.Error("Failed to find quickstarts metadata files", "error", err)
@@ -77,17 +133,22 @@ func findTags() []MetadataTemplate {
 
 	slog.Info("Found metadata files to process", "total", len(files), "quickstarts", len(quickstartsFiles), "help_topics", len(helpTopicsFiles))
 
-	for _, file := range files {
+	for i, file := range files {
 		tagMetadata, err := readMetadata(file)
 		if err != nil {
 			slog.Warn("Failed to read metadata", "file", file, "error", err)
+			kind := "quickstart"
+			if i >= len(quickstartsFiles) {
+				kind = "helptopic"
+			}
+			itemErrors = append(itemErrors, models.SeedItemError{Kind: kind, File: file, Error: err.Error()})
 		} else {
 			MetadataTemplates = append(MetadataTemplates, tagMetadata)
 		}
 	}
 
-	slog.Info("Successfully parsed metadata templates", "count", len(MetadataTemplates))
-	return MetadataTemplates
+	slog.Info("Successfully parsed metadata templates", "count", len(MetadataTemplates), "errors", len(itemErrors))
+	return MetadataTemplates, itemErrors
 }
 
 func addTags(t MetadataTemplate) ([]byte, error) {
@@ -101,9 +162,17 @@ func addTags(t MetadataTemplate) ([]byte, error) {
 		return []byte{}, err
 	}
 
-	var data map[string]map[string]interface{}
-	json.Unmarshal(jsonContent, &data)
-	data["metadata"]["tags"] = t.Tags
+	// Only the metadata section is edited; other top-level fields such as
+	// kind and apiVersion are strings and are kept as they are.
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &data); err != nil {
+		return []byte{}, fmt.Errorf("quickstart must be a YAML mapping: %w", err)
+	}
+	metadata, ok := data["metadata"].(map[string]interface{})
+	if !ok {
+		return []byte{}, fmt.Errorf("quickstart has no metadata section")
+	}
+	metadata["tags"] = t.Tags
 
 	jsonContent, err = json.Marshal(data)
 
@@ -114,14 +183,27 @@ func seedQuickstart(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) (mod
 	var newQuickstart models.Quickstart
 	var originalQuickstart models.Quickstart
 
+	lifecycle, err := t.Lifecycle()
+	if err != nil {
+		return newQuickstart, err
+	}
 	jsonContent, err := addTags(t)
 	if err != nil {
 		slog.Error("Failed to add tags for quickstart", "path", t.ContentPath, "error", err)
 		return newQuickstart, err
 	}
-	var data map[string]map[string]string
-	json.Unmarshal(jsonContent, &data)
-	name := data["metadata"]["name"]
+	var data struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(jsonContent, &data); err != nil {
+		return newQuickstart, fmt.Errorf("invalid quickstart metadata: %w", err)
+	}
+	name := data.Metadata.Name
+	if name == "" {
+		return newQuickstart, fmt.Errorf("quickstart metadata has no name")
+	}
 	r := tx.Where("name = ?", name).Find(&originalQuickstart)
 	if r.Error != nil {
 		// check for DB error
@@ -131,6 +213,7 @@ func seedQuickstart(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) (mod
 		// Create new quickstart
 		newQuickstart.Content = jsonContent
 		newQuickstart.Name = name
+		newQuickstart.Lifecycle = lifecycle
 		if err := tx.Create(&newQuickstart).Error; err != nil {
 			slog.Error("Failed to create quickstart", "name", name, "error", err)
 			return newQuickstart, err
@@ -149,6 +232,7 @@ func seedQuickstart(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) (mod
 	} else {
 		// Update existing quickstart
 		originalQuickstart.Content = jsonContent
+		originalQuickstart.Lifecycle = lifecycle
 		// Clear all tags associations
 		if err := tx.Model(&originalQuickstart).Association("Tags").Clear(); err != nil {
 			slog.Error("Failed clearing tags associations for quickstart", "name", name, "error", err)
@@ -212,8 +296,12 @@ func seedDefaultTags(tx *gorm.DB) (map[string]models.Tag, error) {
 }
 
 func seedHelpTopic(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) ([]models.HelpTopic, error) {
-	yamlfile, err := ioutil.ReadFile(t.ContentPath)
 	returnValue := make([]models.HelpTopic, 0)
+	lifecycle, err := t.Lifecycle()
+	if err != nil {
+		return returnValue, err
+	}
+	yamlfile, err := ioutil.ReadFile(t.ContentPath)
 	if err != nil {
 		slog.Error("Failed to read help topic file", "path", t.ContentPath, "error", err)
 		return returnValue, err
@@ -230,10 +318,18 @@ func seedHelpTopic(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) ([]mo
 		return returnValue, err
 	}
 
+	// Check every topic before writing any, so an invalid group is skipped
+	// as a whole instead of being left half seeded.
+	for i, c := range d {
+		if name, _ := c["name"].(string); name == "" {
+			return returnValue, fmt.Errorf("help topic %d has no name", i)
+		}
+	}
+
 	for _, c := range d {
 		var newHelpTopic models.HelpTopic
 		var originalHelpTopic models.HelpTopic
-		name := c["name"]
+		name := c["name"].(string)
 		r := tx.Where("name = ?", name).Find(&originalHelpTopic)
 
 		if r.Error != nil {
@@ -248,7 +344,8 @@ func seedHelpTopic(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) ([]mo
 				slog.Error("Failed to marshal content for help topic", "name", name, "error", err)
 				return returnValue, err
 			}
-			newHelpTopic.Name = fmt.Sprintf("%v", name)
+			newHelpTopic.Name = name
+			newHelpTopic.Lifecycle = lifecycle
 			if err := tx.Create(&newHelpTopic).Error; err != nil {
 				slog.Error("Failed to create help topic", "name", name, "error", err)
 				return returnValue, err
@@ -267,6 +364,7 @@ func seedHelpTopic(tx *gorm.DB, t MetadataTemplate, defaultTag models.Tag) ([]mo
 			// Update existing help topic
 			originalHelpTopic.Content, err = json.Marshal(c)
 			originalHelpTopic.GroupName = t.Name
+			originalHelpTopic.Lifecycle = lifecycle
 			if err != nil {
 				slog.Error("Failed to marshal content for help topic", "name", name, "error", err)
 				return returnValue, err
@@ -305,6 +403,11 @@ func clearOldContent(tx *gorm.DB) ([]models.FavoriteQuickstart, error) {
 	var staleHelpTopics []models.HelpTopic
 	tx.Model(&models.FavoriteQuickstart{}).Find(&favorites)
 
+	if err := tx.Where("1 = 1").Delete(&models.QuickstartAlias{}).Error; err != nil {
+		slog.Error("Failed to delete quickstart aliases", "error", err)
+		return favorites, fmt.Errorf("failed to delete quickstart aliases: %w", err)
+	}
+
 	tx.Model(&models.Quickstart{}).Find(&staleQuickstarts)
 	tx.Model(&models.HelpTopic{}).Find(&staleHelpTopics)
 
@@ -393,6 +496,98 @@ func seedFavorites(tx *gorm.DB, favorites []models.FavoriteQuickstart) error {
 	return nil
 }
 
+// quickstartAliases maps the previous names listed by the seeded quickstarts to
+// their current names. A previous name that is still a quickstart name, or
+// that two quickstarts list, is reported and left out.
+func quickstartAliases(renamed map[string]MetadataTemplate, seeded map[string]bool, file func(string) string) (map[string]string, []models.SeedItemError) {
+	aliases := map[string]string{}
+	claimedBy := map[string]string{}
+	var itemErrors []models.SeedItemError
+	names := make([]string, 0, len(renamed))
+	for name := range renamed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		template := renamed[name]
+		for _, previous := range template.PreviousNames {
+			var reason string
+			switch other, claimed := claimedBy[previous]; {
+			case previous == name:
+				reason = fmt.Sprintf("previous name %q is the quickstart's own name", previous)
+			case seeded["quickstart/"+previous]:
+				reason = fmt.Sprintf("previous name %q is the name of another quickstart", previous)
+			case claimed:
+				reason = fmt.Sprintf("previous name %q is also listed by quickstart %q", previous, other)
+				delete(aliases, previous)
+			}
+			if reason != "" {
+				itemErrors = append(itemErrors, models.SeedItemError{Kind: "quickstart", File: file(template.ContentPath), Error: reason})
+				continue
+			}
+			claimedBy[previous] = name
+			aliases[previous] = name
+		}
+	}
+	return aliases, itemErrors
+}
+
+// seedAliases stores aliases and moves favorites and progress recorded under
+// a previous name to the current one. It rewrites favorites in place, before
+// seedFavorites re-creates them; an account keeps one favorite per quickstart.
+func seedAliases(tx *gorm.DB, aliases map[string]string, favorites []models.FavoriteQuickstart) ([]models.FavoriteQuickstart, error) {
+	if len(aliases) == 0 {
+		return favorites, nil
+	}
+	rows := make([]models.QuickstartAlias, 0, len(aliases))
+	for previous, current := range aliases {
+		rows = append(rows, models.QuickstartAlias{Name: previous, QuickstartName: current})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	if err := tx.Create(&rows).Error; err != nil {
+		slog.Error("Failed to create quickstart aliases", "error", err)
+		return favorites, fmt.Errorf("failed to create quickstart aliases: %w", err)
+	}
+
+	movedFavorites := 0
+	kept := make([]models.FavoriteQuickstart, 0, len(favorites))
+	index := map[string]int{}
+	for _, favorite := range favorites {
+		if current, ok := aliases[favorite.QuickstartName]; ok {
+			favorite.QuickstartName = current
+			movedFavorites++
+		}
+		key := favorite.AccountId + "/" + favorite.QuickstartName
+		if i, ok := index[key]; ok {
+			kept[i].Favorite = kept[i].Favorite || favorite.Favorite
+			continue
+		}
+		index[key] = len(kept)
+		kept = append(kept, favorite)
+	}
+
+	var movedProgress int64
+	for _, row := range rows {
+		// Accounts with progress under both names keep the current one.
+		existing := tx.Unscoped().Model(&models.QuickstartProgress{}).
+			Select("account_id").Where("quickstart_name = ?", row.QuickstartName)
+		result := tx.Unscoped().Model(&models.QuickstartProgress{}).
+			Where("quickstart_name = ? AND account_id NOT IN (?)", row.Name, existing).
+			Update("quickstart_name", row.QuickstartName)
+		if result.Error != nil {
+			slog.Error("Failed to move progress to renamed quickstart", "from", row.Name, "to", row.QuickstartName, "error", result.Error)
+			return kept, fmt.Errorf("failed to move progress from %s to %s: %w", row.Name, row.QuickstartName, result.Error)
+		}
+		movedProgress += result.RowsAffected
+	}
+
+	slog.Info("Seeded quickstart aliases",
+		"aliases", len(rows),
+		"moved_favorites", movedFavorites,
+		"moved_progress", movedProgress)
+	return kept, nil
+}
+
 // findOrCreateTag looks up a tag by type and value, creating it if it doesn't
 // exist. The preload parameter specifies which association to preload
 // ("Quickstarts" or "HelpTopics").
@@ -433,16 +628,116 @@ func acquireAdvisoryLockIfSupported(tx *gorm.DB) {
 	}
 }
 
-func SeedTags() {
+// bumpSeedGeneration increments the seed generation marker so that replicas
+// holding an in-memory copy of the catalog know to reload it. source and
+// revision record where the new content came from.
+func bumpSeedGeneration(tx *gorm.DB, source, revision string) error {
+	marker := models.SeedGeneration{ID: models.SeedGenerationID}
+	if err := tx.FirstOrCreate(&marker, models.SeedGeneration{ID: models.SeedGenerationID}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&marker).Updates(map[string]interface{}{
+		"generation": gorm.Expr("generation + 1"),
+		"source":     source,
+		"revision":   revision,
+	}).Error
+}
+
+// CurrentSeedGeneration returns the generation of the last successful seed,
+// or 0 if the database has never been seeded.
+func CurrentSeedGeneration(db *gorm.DB) (int64, error) {
+	marker, err := CurrentSeed(db)
+	return marker.Generation, err
+}
+
+// CurrentSeed returns the seed generation marker, which is zero if the
+// database has never been seeded, and publishes its source and revision as
+// quickstarts_content_revision_info.
+func CurrentSeed(db *gorm.DB) (models.SeedGeneration, error) {
+	var marker models.SeedGeneration
+	r := db.Where("id = ?", models.SeedGenerationID).Limit(1).Find(&marker)
+	if r.Error == nil && marker.Generation > 0 {
+		recordContentRevision(marker.Source, marker.Revision)
+	}
+	return marker, r.Error
+}
+
+// SeedContentError reports the invalid content files that made a seed run
+// fail, either because strict mode is on or because they exceeded the error
+// budget.
+type SeedContentError struct {
+	Errors []models.SeedItemError
+	Budget int // Error budget that was exceeded; negative in strict mode
+}
+
+func (e *SeedContentError) Error() string {
+	var b strings.Builder
+	if e.Budget < 0 {
+		fmt.Fprintf(&b, "strict mode: %d invalid content files", len(e.Errors))
+	} else {
+		fmt.Fprintf(&b, "%d invalid content files exceed the error budget of %d", len(e.Errors), e.Budget)
+	}
+	for _, item := range e.Errors {
+		fmt.Fprintf(&b, "; %s %s: %s", item.Kind, item.File, item.Error)
+	}
+	return b.String()
+}
+
+// checkContentErrors returns a *SeedContentError when itemErrors must abort the
+// seed run: in strict mode any error does, otherwise only more errors than
+// SEED_ERROR_BUDGET allows.
+func checkContentErrors(itemErrors []models.SeedItemError) error {
+	if len(itemErrors) == 0 {
+		return nil
+	}
+	cfg := config.Get()
+	if cfg.SeedStrict {
+		return &SeedContentError{Errors: itemErrors, Budget: -1}
+	}
+	if cfg.SeedErrorBudget >= 0 && len(itemErrors) > cfg.SeedErrorBudget {
+		return &SeedContentError{Errors: itemErrors, Budget: cfg.SeedErrorBudget}
+	}
+	return nil
+}
+
+// SeedTags replaces the catalog with the content from OpenContentSource in a
+// single transaction. Invalid content files are skipped and recorded on the
+// seed run unless SEED_STRICT or SEED_ERROR_BUDGET make them abort it, in
+// which case the returned error is a *SeedContentError and nothing changes.
+func SeedTags() error {
 	slog.Info("Starting database seeding process...")
+	start := time.Now()
+	run := models.SeedRun{StartedAt: start}
+	var counts map[string]map[string]int
+
+	src, err := OpenContentSource()
+	if err != nil {
+		slog.Error("Failed to prepare content source", "error", err)
+		recordSeedRun(start, err, nil)
+		saveSeedRun(&run, err)
+		return err
+	}
+	defer src.Close()
+	run.Source, run.Revision = src.Source, src.Revision
+	slog.Info("Seeding content", "source", src.Source, "revision", src.Revision)
 
 	// Pre-compute metadata templates outside the transaction since this
 	// only reads YAML files from disk and does not touch the database.
-	MetadataTemplates := findTags()
+	MetadataTemplates, metadataErrors := findTags(src.Dir)
+	for _, item := range metadataErrors {
+		item.File = src.File(item.File)
+		run.Errors = append(run.Errors, item)
+	}
 
-	err := DB.Transaction(func(tx *gorm.DB) error {
+	err = DB.Transaction(func(tx *gorm.DB) error {
 		acquireAdvisoryLockIfSupported(tx)
 
+		previous, err := catalogKeys(tx)
+		if err != nil {
+			return fmt.Errorf("load current catalog failed: %w", err)
+		}
+		seeded := map[string]bool{}
+
 		// clear old content phase
 		favorites, err := clearOldContent(tx)
 		if err != nil {
@@ -455,9 +750,8 @@ func SeedTags() {
 		}
 
 		quickstartCount := 0
-		quickstartErrorCount := 0
 		helpTopicCount := 0
-		helpTopicErrorCount := 0
+		renamed := map[string]MetadataTemplate{}
 
 		slog.Info("Processing templates...", "count", len(MetadataTemplates))
 
@@ -470,13 +764,19 @@ func SeedTags() {
 				quickstart, quickstartErr = seedQuickstart(tx, template, defaultTags["quickstart"])
 				if quickstartErr != nil {
 					slog.Error("Unable to seed quickstart", "path", template.ContentPath, "error", quickstartErr)
-					quickstartErrorCount++
+					run.Errors = append(run.Errors, models.SeedItemError{Kind: "quickstart", File: src.File(template.ContentPath), Error: quickstartErr.Error()})
 					continue
 				}
 				quickstartCount++
+				seeded["quickstart/"+quickstart.Name] = true
+				if len(template.PreviousNames) > 0 {
+					renamed[quickstart.Name] = template
+				}
 
-				// Clear all tags associations
+				// Clear all tags associations and record where the content came from
 				quickstart.Tags = tags
+				quickstart.SourceFile = src.File(template.ContentPath)
+				quickstart.SourceRevision = src.Revision
 				if err := tx.Save(&quickstart).Error; err != nil {
 					slog.Error("Failed to save quickstart after clearing tags", "name", quickstart.Name, "error", err)
 					return fmt.Errorf("failed to save quickstart %s: %w", quickstart.Name, err)
@@ -514,10 +814,13 @@ func SeedTags() {
 				helpTopic, helpTopicErr := seedHelpTopic(tx, template, defaultTags["helptopic"])
 				if helpTopicErr != nil {
 					slog.Error("Unable to seed help topic", "path", template.ContentPath, "error", helpTopicErr)
-					helpTopicErrorCount++
+					run.Errors = append(run.Errors, models.SeedItemError{Kind: "helptopic", File: src.File(template.ContentPath), Error: helpTopicErr.Error()})
 					continue
 				}
 				helpTopicCount += len(helpTopic)
+				for _, h := range helpTopic {
+					seeded["helptopic/"+h.Name] = true
+				}
 
 				for _, tagTemplate := range template.Tags {
 					foundTag, err := findOrCreateTag(tx, "HelpTopics",
@@ -548,22 +851,126 @@ func SeedTags() {
 			}
 		}
 
+		aliases, aliasErrors := quickstartAliases(renamed, seeded, src.File)
+		run.Errors = append(run.Errors, aliasErrors...)
+
+		quickstartErrorCount, helpTopicErrorCount := 0, 0
+		for _, item := range run.Errors {
+			if item.Kind == "quickstart" {
+				quickstartErrorCount++
+			} else {
+				helpTopicErrorCount++
+			}
+		}
+		run.QuickstartErrors = quickstartErrorCount
+		run.HelpTopicErrors = helpTopicErrorCount
+
 		slog.Info("Content seeding summary",
 			"quickstarts", quickstartCount,
 			"quickstart_errors", quickstartErrorCount,
 			"help_topics", helpTopicCount,
 			"help_topic_errors", helpTopicErrorCount)
 
+		if err := checkContentErrors(run.Errors); err != nil {
+			return err
+		}
+		favorites, err = seedAliases(tx, aliases, favorites)
+		if err != nil {
+			return fmt.Errorf("seed quickstart aliases failed: %w", err)
+		}
 		if err := seedFavorites(tx, favorites); err != nil {
 			return fmt.Errorf("seed favorites failed: %w", err)
 		}
+		if err := bumpSeedGeneration(tx, src.Source, src.Revision); err != nil {
+			return fmt.Errorf("bump seed generation failed: %w", err)
+		}
+		counts = map[string]map[string]int{
+			"quickstart": {"seeded": quickstartCount, "failed": quickstartErrorCount},
+			"helptopic":  {"seeded": helpTopicCount, "failed": helpTopicErrorCount},
+		}
+		for key := range seeded {
+			if previous[key] {
+				run.Updated++
+			} else {
+				run.Created++
+			}
+		}
+		for key := range previous {
+			if !seeded[key] {
+				run.Deleted++
+			}
+		}
 		return nil
 	})
+	recordSeedRun(start, err, counts)
+	saveSeedRun(&run, err)
 
 	if err != nil {
 		slog.Error("Database seeding transaction failed", "error", err)
+		return err
+	}
+
+	recordContentRevision(src.Source, src.Revision)
+	slog.Info("Database seeding completed successfully", "source", src.Source, "revision", src.Revision)
+	return nil
+}
+
+// catalogKeys returns a "kind/name" key for every quickstart and help topic,
+// so a seed run can tell created items from updated ones.
+func catalogKeys(tx *gorm.DB) (map[string]bool, error) {
+	var quickstarts, helpTopics []string
+	if err := tx.Model(&models.Quickstart{}).Pluck("name", &quickstarts).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.HelpTopic{}).Pluck("name", &helpTopics).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(quickstarts)+len(helpTopics))
+	for _, name := range quickstarts {
+		keys["quickstart/"+name] = true
+	}
+	for _, name := range helpTopics {
+		keys["helptopic/"+name] = true
+	}
+	return keys, nil
+}
+
+// saveSeedRun stores the outcome of a seeding run and prunes runs beyond
+// SEED_RUN_RETENTION. It runs outside the seeding transaction, so failed runs
+// are recorded too. A failed run was rolled back and changed nothing.
+func saveSeedRun(run *models.SeedRun, err error) {
+	run.FinishedAt = time.Now()
+	run.Status = models.SeedRunSucceeded
+	if err != nil {
+		run.Status = models.SeedRunFailed
+		run.Error = err.Error()
+		run.Created, run.Updated, run.Deleted = 0, 0, 0
+	}
+	if err := DB.Create(run).Error; err != nil {
+		slog.Warn("Failed to record seed run", "error", err)
 		return
 	}
+	pruneSeedRuns(config.Get().SeedRunRetention)
+}
 
-	slog.Info("Database seeding completed successfully")
+// pruneSeedRuns deletes all but the newest keep seed runs. IDs increase with
+// every run, so everything at or below the first ID past keep goes.
+func pruneSeedRuns(keep int) {
+	if keep <= 0 {
+		return
+	}
+	var cutoff []uint
+	if err := DB.Model(&models.SeedRun{}).Order("id DESC").Offset(keep).Limit(1).Pluck("id", &cutoff).Error; err != nil {
+		slog.Warn("Failed to prune seed runs", "error", err)
+		return
+	}
+	if len(cutoff) == 0 {
+		return
+	}
+	result := DB.Where("id <= ?", cutoff[0]).Delete(&models.SeedRun{})
+	if result.Error != nil {
+		slog.Warn("Failed to prune seed runs", "error", result.Error)
+		return
+	}
+	slog.Info("Pruned seed runs", "deleted", result.RowsAffected, "kept", keep)
 }
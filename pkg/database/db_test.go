@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateTags(t *testing.T) {
@@ -152,6 +156,18 @@ func TestIdempotentReseeding(t *testing.T) {
 		assert.Equal(t, len(firstHelpTopics), len(secondHelpTopics), "help topic count should be stable across re-seeds")
 		assert.Equal(t, len(firstTags), len(secondTags), "tag count should be stable across re-seeds")
 	})
+
+	t.Run("each successful seed bumps the seed generation", func(t *testing.T) {
+		before, err := CurrentSeedGeneration(DB)
+		assert.NoError(t, err)
+
+		SeedTags()
+
+		after, err := CurrentSeed(DB)
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, after.Generation)
+		assert.Equal(t, contentDir(), after.Source, "the marker records where the content came from")
+	})
 }
 
 func TestDBSeeding(t *testing.T) {
@@ -174,7 +190,7 @@ func TestDBSeeding(t *testing.T) {
 
 	t.Run("DB contains correct quickstart data", func(t *testing.T) {
 		var metadataTemplates []MetadataTemplate
-		metadataTemplates = findTags()
+		metadataTemplates, _ = findTags(contentDir())
 
 		for _, template := range metadataTemplates {
 			if template.Kind == "QuickStarts" {
@@ -197,7 +213,7 @@ func TestDBSeeding(t *testing.T) {
 	})
 	t.Run("DB contains correct help topic data", func(t *testing.T) {
 		var metadataTemplates []MetadataTemplate
-		metadataTemplates = findTags()
+		metadataTemplates, _ = findTags(contentDir())
 
 		for _, template := range metadataTemplates {
 			if template.Kind == "HelpTopic" {
@@ -222,3 +238,301 @@ func TestDBSeeding(t *testing.T) {
 		}
 	})
 }
+
+func TestSeedRuns(t *testing.T) {
+	lastRun := func(t *testing.T) models.SeedRun {
+		t.Helper()
+		var run models.SeedRun
+		require.NoError(t, DB.Order("id DESC").First(&run).Error)
+		return run
+	}
+
+	t.Run("records created, updated and deleted items", func(t *testing.T) {
+		stale := models.Quickstart{Name: "seed-run-stale-qs", Content: []byte(`{}`)}
+		require.NoError(t, DB.Create(&stale).Error)
+		var quickstarts, helpTopics int64
+		DB.Model(&models.Quickstart{}).Count(&quickstarts)
+		DB.Model(&models.HelpTopic{}).Count(&helpTopics)
+
+		SeedTags()
+
+		run := lastRun(t)
+		assert.Equal(t, models.SeedRunSucceeded, run.Status)
+		assert.Equal(t, contentDir(), run.Source)
+		assert.Equal(t, 0, run.Created)
+		assert.EqualValues(t, quickstarts+helpTopics-1, run.Updated)
+		assert.Equal(t, 1, run.Deleted, "the stale quickstart is not in the content directory")
+		assert.Empty(t, run.Errors)
+		assert.False(t, run.FinishedAt.Before(run.StartedAt))
+
+		var q models.Quickstart
+		require.NoError(t, DB.Where("source_file <> ''").First(&q).Error)
+		assert.Regexp(t, `^quickstarts/[^/]+/[^/]+\.ya?ml$`, q.SourceFile)
+		assert.Equal(t, run.Revision, q.SourceRevision)
+	})
+
+	t.Run("records failed runs", func(t *testing.T) {
+		cfg := config.Get()
+		previous := cfg.ContentArchive
+		cfg.ContentArchive = filepath.Join(t.TempDir(), "missing.tar.gz")
+		t.Cleanup(func() { cfg.ContentArchive = previous })
+
+		SeedTags()
+
+		run := lastRun(t)
+		assert.Equal(t, models.SeedRunFailed, run.Status)
+		assert.Contains(t, run.Error, "missing.tar.gz")
+	})
+
+	t.Run("keeps only the newest runs", func(t *testing.T) {
+		cfg := config.Get()
+		previous := cfg.SeedRunRetention
+		cfg.SeedRunRetention = 2
+		t.Cleanup(func() { cfg.SeedRunRetention = previous })
+
+		SeedTags()
+		SeedTags()
+		newest := lastRun(t)
+		SeedTags()
+
+		var ids []uint
+		require.NoError(t, DB.Model(&models.SeedRun{}).Order("id").Pluck("id", &ids).Error)
+		require.Len(t, ids, 2)
+		assert.Equal(t, newest.ID, ids[0])
+	})
+}
+
+func TestSeedHelpTopicChecksGroupBeforeWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "group.yml")
+	require.NoError(t, os.WriteFile(path, []byte("- name: seed-partial-first\n  title: First\n- title: No name\n"), 0o644))
+
+	tx := DB.Begin()
+	defer tx.Rollback()
+	topics, err := seedHelpTopic(tx, MetadataTemplate{Name: "seed-partial", ContentPath: path}, models.Tag{})
+	assert.EqualError(t, err, "help topic 1 has no name")
+	assert.Empty(t, topics)
+
+	var count int64
+	tx.Model(&models.HelpTopic{}).Where("name = ?", "seed-partial-first").Count(&count)
+	assert.Zero(t, count, "no topic of an invalid group is written")
+}
+
+func TestSeedContentErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeContent := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o644))
+	}
+	writeContent("quickstarts/good/metadata.yml", "kind: QuickStarts\nname: good\n")
+	writeContent("quickstarts/good/good.yml", "apiVersion: console.openshift.io/v1\nkind: QuickStarts\nmetadata:\n  name: seed-errors-good\nspec:\n  displayName: Good\n")
+	// No metadata section: addTags used to panic on this.
+	writeContent("quickstarts/nometa/metadata.yml", "kind: QuickStarts\nname: nometa\n")
+	writeContent("quickstarts/nometa/nometa.yml", "apiVersion: console.openshift.io/v1\nkind: QuickStarts\nspec: {}\n")
+	// Unreadable metadata: findTags used to drop it with a warning.
+	writeContent("help-topics/broken/metadata.yml", "kind: [HelpTopic\n")
+
+	cfg := config.Get()
+	previousCfg := *cfg
+	previousDir := os.Getenv("QUICKSTARTS_CONTENT_DIR")
+	os.Setenv("QUICKSTARTS_CONTENT_DIR", dir)
+	t.Cleanup(func() {
+		*cfg = previousCfg
+		os.Setenv("QUICKSTARTS_CONTENT_DIR", previousDir)
+		require.NoError(t, SeedTags())
+	})
+
+	seeded := func() bool {
+		var count int64
+		DB.Model(&models.Quickstart{}).Where("name = ?", "seed-errors-good").Count(&count)
+		return count > 0
+	}
+	lastRun := func() models.SeedRun {
+		var run models.SeedRun
+		require.NoError(t, DB.Order("id DESC").First(&run).Error)
+		return run
+	}
+	expected := []models.SeedItemError{
+		{Kind: "quickstart", File: "quickstarts/nometa/nometa.yml", Error: "quickstart has no metadata section"},
+		{Kind: "helptopic", File: "help-topics/broken/metadata.yml"},
+	}
+	assertErrors := func(t *testing.T, items []models.SeedItemError) {
+		t.Helper()
+		require.Len(t, items, 2)
+		files := map[string]models.SeedItemError{}
+		for _, item := range items {
+			files[item.File] = item
+		}
+		for _, want := range expected {
+			got, ok := files[want.File]
+			require.True(t, ok, "missing error for %s", want.File)
+			assert.Equal(t, want.Kind, got.Kind)
+			if want.Error != "" {
+				assert.Equal(t, want.Error, got.Error)
+			}
+		}
+	}
+
+	t.Run("strict mode aborts with every error", func(t *testing.T) {
+		cfg.SeedStrict = true
+		t.Cleanup(func() { cfg.SeedStrict = false })
+
+		err := SeedTags()
+		var contentErr *SeedContentError
+		require.ErrorAs(t, err, &contentErr)
+		assert.Equal(t, -1, contentErr.Budget)
+		assertErrors(t, contentErr.Errors)
+		assert.Contains(t, err.Error(), "strict mode: 2 invalid content files")
+		assert.False(t, seeded(), "the transaction is rolled back")
+
+		run := lastRun()
+		assert.Equal(t, models.SeedRunFailed, run.Status)
+		assert.Equal(t, 1, run.QuickstartErrors)
+		assert.Equal(t, 1, run.HelpTopicErrors)
+		assertErrors(t, run.Errors)
+	})
+
+	t.Run("lenient mode aborts past the error budget", func(t *testing.T) {
+		cfg.SeedErrorBudget = 1
+		err := SeedTags()
+		var contentErr *SeedContentError
+		require.ErrorAs(t, err, &contentErr)
+		assert.Equal(t, 1, contentErr.Budget)
+		assert.Contains(t, err.Error(), "2 invalid content files exceed the error budget of 1")
+		assert.False(t, seeded())
+	})
+
+	t.Run("lenient mode skips invalid files within the budget", func(t *testing.T) {
+		for _, budget := range []int{2, -1} {
+			cfg.SeedErrorBudget = budget
+			require.NoError(t, SeedTags())
+			assert.True(t, seeded())
+
+			run := lastRun()
+			assert.Equal(t, models.SeedRunSucceeded, run.Status)
+			assertErrors(t, run.Errors)
+		}
+	})
+}
+
+func TestSeedQuickstartRenames(t *testing.T) {
+	dir := t.TempDir()
+	writeQuickstart := func(name, metadata string) {
+		t.Helper()
+		base := filepath.Join(dir, "quickstarts", name)
+		require.NoError(t, os.MkdirAll(base, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(base, "metadata.yml"), []byte("kind: QuickStarts\nname: "+name+"\n"+metadata), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(base, name+".yml"), []byte("apiVersion: console.openshift.io/v1\nkind: QuickStarts\nmetadata:\n  name: "+name+"\nspec:\n  displayName: "+name+"\n"), 0o644))
+	}
+	writeQuickstart("rename-new", "previousNames:\n  - rename-old\n  - rename-older\n")
+	writeQuickstart("rename-other", "previousNames:\n  - rename-new\n")
+
+	previousDir := os.Getenv("QUICKSTARTS_CONTENT_DIR")
+	os.Setenv("QUICKSTARTS_CONTENT_DIR", dir)
+	t.Cleanup(func() {
+		os.Setenv("QUICKSTARTS_CONTENT_DIR", previousDir)
+		require.NoError(t, SeedTags())
+		DB.Unscoped().Where("quickstart_name LIKE ?", "rename-%").Delete(&models.QuickstartProgress{})
+	})
+
+	// Seed once so the favorites below survive as favorites of a known quickstart.
+	require.NoError(t, SeedTags())
+	require.NoError(t, DB.Create([]models.FavoriteQuickstart{
+		{AccountId: "rename-1", QuickstartName: "rename-old", Favorite: true},
+		{AccountId: "rename-2", QuickstartName: "rename-older", Favorite: true},
+		{AccountId: "rename-2", QuickstartName: "rename-new", Favorite: false},
+	}).Error)
+	require.NoError(t, DB.Create([]models.QuickstartProgress{
+		{AccountId: 9001, QuickstartName: "rename-old"},
+		{AccountId: 9002, QuickstartName: "rename-old"},
+		{AccountId: 9002, QuickstartName: "rename-new"},
+	}).Error)
+
+	require.NoError(t, SeedTags())
+
+	var aliases []models.QuickstartAlias
+	require.NoError(t, DB.Order("name").Find(&aliases).Error)
+	assert.Equal(t, []models.QuickstartAlias{
+		{Name: "rename-old", QuickstartName: "rename-new"},
+		{Name: "rename-older", QuickstartName: "rename-new"},
+	}, aliases)
+
+	var run models.SeedRun
+	require.NoError(t, DB.Order("id DESC").First(&run).Error)
+	require.Len(t, run.Errors, 1)
+	assert.Equal(t, "quickstarts/rename-other/rename-other.yml", run.Errors[0].File)
+	assert.Contains(t, run.Errors[0].Error, `previous name "rename-new" is the name of another quickstart`)
+
+	var favorites []models.FavoriteQuickstart
+	require.NoError(t, DB.Where("account_id LIKE ?", "rename-%").Order("account_id").Find(&favorites).Error)
+	require.Len(t, favorites, 2)
+	for _, favorite := range favorites {
+		assert.Equal(t, "rename-new", favorite.QuickstartName)
+		assert.True(t, favorite.Favorite)
+	}
+
+	var progress []models.QuickstartProgress
+	require.NoError(t, DB.Where("account_id IN ?", []int{9001, 9002}).Order("account_id, quickstart_name").Find(&progress).Error)
+	require.Len(t, progress, 3)
+	assert.Equal(t, "rename-new", progress[0].QuickstartName, "progress moves to the current name")
+	assert.Equal(t, "rename-new", progress[1].QuickstartName)
+	assert.Equal(t, "rename-old", progress[2].QuickstartName, "progress already under the current name wins")
+}
+
+func TestSeedLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	write := func(file, content string) {
+		t.Helper()
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	writeQuickstart := func(name, metadata string) {
+		t.Helper()
+		write(filepath.Join("quickstarts", name, "metadata.yml"), "kind: QuickStarts\nname: "+name+"\n"+metadata)
+		write(filepath.Join("quickstarts", name, name+".yml"), "apiVersion: console.openshift.io/v1\nkind: QuickStarts\nmetadata:\n  name: "+name+"\nspec:\n  displayName: "+name+"\n")
+	}
+	writeQuickstart("lifecycle-scheduled", "publishAt: 2030-01-01\nunpublishAt: \"2031-06-01T12:00:00+02:00\"\n")
+	writeQuickstart("lifecycle-deprecated", "status: deprecated\ndeprecation:\n  replacement: lifecycle-scheduled\n")
+	writeQuickstart("lifecycle-invalid", "status: retired\n")
+	write("help-topics/lifecycle/metadata.yml", "kind: HelpTopic\nname: lifecycle\nstatus: draft\n")
+	write("help-topics/lifecycle/lifecycle.yml", "- name: lifecycle-topic\n  title: Topic\n  content: Text\n")
+
+	previousDir := os.Getenv("QUICKSTARTS_CONTENT_DIR")
+	os.Setenv("QUICKSTARTS_CONTENT_DIR", dir)
+	t.Cleanup(func() {
+		os.Setenv("QUICKSTARTS_CONTENT_DIR", previousDir)
+		require.NoError(t, SeedTags())
+	})
+	require.NoError(t, SeedTags())
+
+	var scheduled, deprecated models.Quickstart
+	require.NoError(t, DB.Where("name = ?", "lifecycle-scheduled").First(&scheduled).Error)
+	assert.Equal(t, models.StatusPublished, scheduled.Status)
+	require.NotNil(t, scheduled.PublishAt)
+	require.NotNil(t, scheduled.UnpublishAt)
+	assert.True(t, scheduled.PublishAt.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, scheduled.UnpublishAt.Equal(time.Date(2031, 6, 1, 10, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, DB.Where("name = ?", "lifecycle-deprecated").First(&deprecated).Error)
+	assert.True(t, deprecated.Deprecated())
+	assert.Equal(t, "lifecycle-scheduled", deprecated.Replacement)
+
+	var topic models.HelpTopic
+	require.NoError(t, DB.Where("name = ?", "lifecycle-topic").First(&topic).Error)
+	assert.Equal(t, models.StatusDraft, topic.Status)
+
+	var run models.SeedRun
+	require.NoError(t, DB.Order("id DESC").First(&run).Error)
+	require.Len(t, run.Errors, 1)
+	assert.Equal(t, "quickstarts/lifecycle-invalid/lifecycle-invalid.yml", run.Errors[0].File)
+	assert.Contains(t, run.Errors[0].Error, `unknown status "retired"`)
+}
+
+func TestSeedShippedContentStrict(t *testing.T) {
+	cfg := config.Get()
+	cfg.SeedStrict = true
+	t.Cleanup(func() { cfg.SeedStrict = false })
+
+	assert.NoError(t, SeedTags(), "the content in docs/ must pass strict mode")
+}
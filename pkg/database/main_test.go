@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/RedHatInsights/quickstarts/config"
-	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/joho/godotenv"
 )
 
@@ -46,7 +45,7 @@ func setUp() {
 	}
 
 	Init()
-	err = DB.AutoMigrate(&models.Tag{}, &models.Quickstart{}, &models.QuickstartProgress{}, &models.HelpTopic{}, &models.FavoriteQuickstart{})
+	err = MigrateTestSchema(DB)
 	if err != nil {
 		panic(err)
 	}
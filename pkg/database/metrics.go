@@ -0,0 +1,105 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	p "github.com/prometheus/client_golang/prometheus"
+	pa "github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "quickstarts:query_start"
+
+var (
+	dbQueryDuration = pa.NewHistogramVec(p.HistogramOpts{
+		Name:    "quickstarts_db_query_duration_seconds",
+		Help:    "Duration of database statements by operation and table",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+	dbQueryErrors = pa.NewCounterVec(p.CounterOpts{
+		Name: "quickstarts_db_query_errors_total",
+		Help: "Total number of failed database statements by operation and table",
+	}, []string{"operation", "table"})
+
+	seedRuns = pa.NewCounterVec(p.CounterOpts{
+		Name: "quickstarts_seed_runs_total",
+		Help: "Total number of content seeding runs by result",
+	}, []string{"result"})
+	seedDuration = pa.NewGauge(p.GaugeOpts{
+		Name: "quickstarts_seed_duration_seconds",
+		Help: "Duration of the last content seeding run",
+	})
+	seedItems = pa.NewGaugeVec(p.GaugeOpts{
+		Name: "quickstarts_seed_items",
+		Help: "Number of items processed by the last content seeding run by kind and result",
+	}, []string{"kind", "result"})
+	seedLastSuccess = pa.NewGauge(p.GaugeOpts{
+		Name: "quickstarts_seed_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful content seeding run",
+	})
+	contentRevision = pa.NewGaugeVec(p.GaugeOpts{
+		Name: "quickstarts_content_revision_info",
+		Help: "Always 1; labels the source and revision of the seeded content",
+	}, []string{"source", "revision"})
+)
+
+// registerMetricsCallbacks times every statement GORM issues. Raw statements
+// without a model are labeled with an empty table.
+func registerMetricsCallbacks(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(queryStartKey, time.Now())
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			table := tx.Statement.Table
+			dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(v.(time.Time)).Seconds())
+			if tx.Error != nil && tx.Error != gorm.ErrRecordNotFound {
+				dbQueryErrors.WithLabelValues(operation, table).Inc()
+			}
+		}
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	)
+}
+
+// recordSeedRun publishes the outcome of a seeding run.
+func recordSeedRun(start time.Time, err error, counts map[string]map[string]int) {
+	seedDuration.Set(time.Since(start).Seconds())
+	if err != nil {
+		seedRuns.WithLabelValues("failure").Inc()
+		return
+	}
+	seedRuns.WithLabelValues("success").Inc()
+	seedLastSuccess.SetToCurrentTime()
+	for kind, results := range counts {
+		for result, n := range results {
+			seedItems.WithLabelValues(kind, result).Set(float64(n))
+		}
+	}
+}
+
+// recordContentRevision replaces the content revision labels with the given
+// source and revision.
+func recordContentRevision(source, revision string) {
+	contentRevision.Reset()
+	contentRevision.WithLabelValues(source, revision).Set(1)
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migration is a versioned schema change loaded from a pair of
+// <version>_<name>.up.sql and <version>_<name>.down.sql files.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of the schema_migrations bookkeeping table.
+type schemaMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamp NOT NULL
+)`
+
+// Migrations returns the migrations embedded in the binary, ordered by
+// version.
+func Migrations() ([]Migration, error) {
+	sub, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(sub)
+}
+
+// LoadMigrations reads the *.sql files at the root of fsys. Every version must
+// have both an up and a down script, and versions must be unique.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		base := strings.TrimSuffix(path.Base(file), ".sql")
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: file name must end in .up.sql or .down.sql", file)
+		}
+		base = strings.TrimSuffix(base, "."+direction)
+
+		prefix, name, ok := strings.Cut(base, "_")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("migration %s: file name must be <version>_<name>.%s.sql", file, direction)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", file, prefix)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %04d_%s: both up and down scripts are required", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// MigrateUp applies every pending migration in version order and returns how
+// many were applied. The whole run happens in one transaction holding the
+// seeding advisory lock, so concurrent migrate jobs and seeds are serialized
+// and a failed migration leaves the schema untouched.
+func MigrateUp(db *gorm.DB, migrations []Migration) (int, error) {
+	applied := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		done, err := lockAndLoadApplied(tx, migrations)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+			slog.Info("Applying migration", "version", m.Version, "name", m.Name)
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return fmt.Errorf("migration %04d_%s up failed: %w", m.Version, m.Name, err)
+			}
+			row := schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now().UTC()}
+			if err := tx.Create(&row).Error; err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return applied, nil
+}
+
+// MigrateDown reverts the most recently applied steps migrations, newest
+// first, and returns how many were reverted.
+func MigrateDown(db *gorm.DB, migrations []Migration, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+
+	reverted := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockAndLoadApplied(tx, migrations); err != nil {
+			return err
+		}
+		var rows []schemaMigration
+		if err := tx.Order("version DESC").Limit(steps).Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		for _, row := range rows {
+			m, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %04d_%s is applied but unknown to this binary", row.Version, row.Name)
+			}
+			slog.Info("Reverting migration", "version", m.Version, "name", m.Name)
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return fmt.Errorf("migration %04d_%s down failed: %w", m.Version, m.Name, err)
+			}
+			if err := tx.Where("version = ?", m.Version).Delete(&schemaMigration{}).Error; err != nil {
+				return fmt.Errorf("failed to unrecord migration %d: %w", m.Version, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reverted, nil
+}
+
+// MigrationStatuses lists every known migration along with when it was
+// applied, if it was.
+func MigrationStatuses(db *gorm.DB, migrations []Migration) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := db.Transaction(func(tx *gorm.DB) error {
+		done, err := lockAndLoadApplied(tx, migrations)
+		if err != nil {
+			return err
+		}
+		statuses = make([]MigrationStatus, 0, len(migrations))
+		for _, m := range migrations {
+			s := MigrationStatus{Migration: m}
+			if row, ok := done[m.Version]; ok {
+				appliedAt := row.AppliedAt
+				s.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// lockAndLoadApplied takes the advisory lock, makes sure schema_migrations
+// exists and returns its rows keyed by version. Applied versions missing from
+// migrations, e.g. after rolling back to an older image, are logged.
+func lockAndLoadApplied(tx *gorm.DB, migrations []Migration) (map[int64]schemaMigration, error) {
+	acquireAdvisoryLockIfSupported(tx)
+
+	if err := tx.Exec(createSchemaMigrations).Error; err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	var rows []schemaMigration
+	if err := tx.Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	known := make(map[int64]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+	}
+	done := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+		if !known[row.Version] {
+			slog.Warn("Database has a migration this binary does not know about", "version", row.Version, "name", row.Name)
+		}
+	}
+	return done, nil
+}
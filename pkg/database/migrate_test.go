@@ -0,0 +1,196 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func testMigrationsFS() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_widgets.up.sql":        {Data: []byte("CREATE TABLE migrate_widgets (id integer PRIMARY KEY, name text)")},
+		"0001_widgets.down.sql":      {Data: []byte("DROP TABLE migrate_widgets")},
+		"0002_widget_color.up.sql":   {Data: []byte("ALTER TABLE migrate_widgets ADD COLUMN color text")},
+		"0002_widget_color.down.sql": {Data: []byte("ALTER TABLE migrate_widgets DROP COLUMN color")},
+		"0010_widget_parts.up.sql":   {Data: []byte("CREATE TABLE migrate_widget_parts (id integer PRIMARY KEY)")},
+		"0010_widget_parts.down.sql": {Data: []byte("DROP TABLE migrate_widget_parts")},
+	}
+}
+
+// resetTestMigrations runs a test against an empty schema_migrations table
+// and records the embedded migrations of the test schema again afterwards.
+func resetTestMigrations(t *testing.T) {
+	require.NoError(t, DB.Exec("DROP TABLE IF EXISTS schema_migrations").Error)
+	t.Cleanup(func() {
+		DB.Exec("DROP TABLE IF EXISTS migrate_widget_parts")
+		DB.Exec("DROP TABLE IF EXISTS migrate_widgets")
+		DB.Exec("DROP TABLE IF EXISTS schema_migrations")
+		require.NoError(t, MigrateTestSchema(DB))
+	})
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(testMigrationsFS())
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "widgets", migrations[0].Name)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, int64(10), migrations[2].Version)
+	assert.Contains(t, migrations[2].Down, "DROP TABLE migrate_widget_parts")
+}
+
+func TestLoadMigrationsRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		fs   fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1")}}},
+		{"missing direction", fstest.MapFS{"0001_a.sql": {Data: []byte("SELECT 1")}}},
+		{"missing name", fstest.MapFS{"0001.up.sql": {Data: []byte("SELECT 1")}, "0001.down.sql": {Data: []byte("SELECT 1")}}},
+		{"bad version", fstest.MapFS{"abc_a.up.sql": {Data: []byte("SELECT 1")}, "abc_a.down.sql": {Data: []byte("SELECT 1")}}},
+		{"conflicting names", fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1")}, "0001_b.down.sql": {Data: []byte("SELECT 1")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fs)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+	for i, m := range migrations {
+		assert.NotContains(t, m.Up, "?", "migration %d must not contain ? placeholders", m.Version)
+		if i > 0 {
+			assert.Greater(t, m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestMigrateUpAndDown(t *testing.T) {
+	resetTestMigrations(t)
+	migrations, err := LoadMigrations(testMigrationsFS())
+	require.NoError(t, err)
+
+	applied, err := MigrateUp(DB, migrations[:2])
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.True(t, DB.Migrator().HasColumn("migrate_widgets", "color"))
+
+	applied, err = MigrateUp(DB, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied, "only the new migration is applied")
+	assert.True(t, DB.Migrator().HasTable("migrate_widget_parts"))
+
+	applied, err = MigrateUp(DB, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied, "rerunning is a no-op")
+
+	statuses, err := MigrationStatuses(DB, migrations)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, s := range statuses {
+		assert.NotNil(t, s.AppliedAt, "migration %d should be applied", s.Version)
+	}
+
+	reverted, err := MigrateDown(DB, migrations, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+	assert.False(t, DB.Migrator().HasTable("migrate_widget_parts"))
+	assert.False(t, DB.Migrator().HasColumn("migrate_widgets", "color"))
+	assert.True(t, DB.Migrator().HasTable("migrate_widgets"))
+
+	statuses, err = MigrationStatuses(DB, migrations)
+	require.NoError(t, err)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+	assert.Nil(t, statuses[2].AppliedAt)
+}
+
+func TestMigrateUpRollsBackOnFailure(t *testing.T) {
+	resetTestMigrations(t)
+	migrations, err := LoadMigrations(fstest.MapFS{
+		"0001_widgets.up.sql":   {Data: []byte("CREATE TABLE migrate_widgets (id integer PRIMARY KEY)")},
+		"0001_widgets.down.sql": {Data: []byte("DROP TABLE migrate_widgets")},
+		"0002_broken.up.sql":    {Data: []byte("ALTER TABLE migrate_missing ADD COLUMN color text")},
+		"0002_broken.down.sql":  {Data: []byte("SELECT 1")},
+	})
+	require.NoError(t, err)
+
+	_, err = MigrateUp(DB, migrations)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "0002_broken")
+	assert.False(t, DB.Migrator().HasTable("migrate_widgets"), "earlier migrations in the run are rolled back")
+}
+
+func TestMigrateDownRequiresKnownMigration(t *testing.T) {
+	resetTestMigrations(t)
+	migrations, err := LoadMigrations(testMigrationsFS())
+	require.NoError(t, err)
+
+	_, err = MigrateUp(DB, migrations)
+	require.NoError(t, err)
+
+	_, err = MigrateDown(DB, migrations[:2], 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown to this binary")
+
+	_, err = MigrateDown(DB, migrations, 0)
+	assert.Error(t, err)
+}
+
+// The baseline must adopt a database created by the old AutoMigrate step,
+// which has the tables but no schema_migrations, and every migration must be
+// safe to run again.
+func TestEmbeddedMigrationsAdoptAutoMigratedSchema(t *testing.T) {
+	if DB.Dialector.Name() != "postgres" {
+		t.Skip("embedded migrations are PostgreSQL only")
+	}
+	require.NoError(t, DB.Exec("DROP TABLE IF EXISTS schema_migrations").Error)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	applied, err := MigrateUp(DB, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, len(migrations), applied)
+
+	applied, err = MigrateUp(DB, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+// The API never runs AutoMigrate, so every model field needs a column in the
+// schema the migrations build. On PostgreSQL the test schema is built by
+// MigrateTestSchema from the migrations alone.
+func TestMigrationsMatchModels(t *testing.T) {
+	if DB.Dialector.Name() != "postgres" {
+		t.Skip("embedded migrations are PostgreSQL only")
+	}
+	migrator := DB.Migrator()
+	for _, model := range schemaModels {
+		stmt := &gorm.Statement{DB: DB}
+		require.NoError(t, stmt.Parse(model))
+		table := stmt.Schema.Table
+		if !assert.True(t, migrator.HasTable(model), "table %s", table) {
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, migrator.HasColumn(model, field.DBName), "column %s.%s", table, field.DBName)
+		}
+		for _, rel := range stmt.Schema.Relationships.Many2Many {
+			assert.True(t, migrator.HasTable(rel.JoinTable.Table), "join table %s", rel.JoinTable.Table)
+		}
+	}
+}
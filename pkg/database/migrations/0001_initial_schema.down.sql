@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS seed_generations;
+DROP TABLE IF EXISTS help_topic_tags;
+DROP TABLE IF EXISTS quickstart_tags;
+DROP TABLE IF EXISTS quickstart_progresses;
+DROP TABLE IF EXISTS favorite_quickstarts;
+DROP TABLE IF EXISTS help_topics;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS quickstarts;
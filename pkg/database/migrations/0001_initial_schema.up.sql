@@ -0,0 +1,90 @@
+-- Baseline schema. Every statement is idempotent so databases created by the
+-- previous GORM AutoMigrate step can be adopted without changes.
+
+CREATE TABLE IF NOT EXISTS quickstarts (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text NOT NULL,
+    content JSONB,
+    CONSTRAINT uni_quickstarts_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_quickstarts_deleted_at ON quickstarts (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    type text NOT NULL,
+    value text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
+
+CREATE TABLE IF NOT EXISTS help_topics (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    group_name text,
+    name text NOT NULL,
+    content JSONB,
+    CONSTRAINT uni_help_topics_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_help_topics_deleted_at ON help_topics (deleted_at);
+
+CREATE TABLE IF NOT EXISTS favorite_quickstarts (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    account_id text NOT NULL,
+    quickstart_name text NOT NULL,
+    favorite boolean
+);
+CREATE INDEX IF NOT EXISTS idx_favorite_quickstarts_deleted_at ON favorite_quickstarts (deleted_at);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_quickstarts_favorite_quickstart') THEN
+        ALTER TABLE favorite_quickstarts
+            ADD CONSTRAINT fk_quickstarts_favorite_quickstart
+            FOREIGN KEY (quickstart_name) REFERENCES quickstarts (name);
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS quickstart_progresses (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    quickstart_name text DEFAULT 'empty',
+    progress JSONB,
+    account_id bigint DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_quickstart_progresses_deleted_at ON quickstart_progresses (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS progress_session ON quickstart_progresses (quickstart_name, account_id);
+
+CREATE TABLE IF NOT EXISTS quickstart_tags (
+    quickstart_id bigint NOT NULL,
+    tag_id bigint NOT NULL,
+    PRIMARY KEY (quickstart_id, tag_id),
+    CONSTRAINT fk_quickstart_tags_quickstart FOREIGN KEY (quickstart_id) REFERENCES quickstarts (id),
+    CONSTRAINT fk_quickstart_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+);
+
+CREATE TABLE IF NOT EXISTS help_topic_tags (
+    help_topic_id bigint NOT NULL,
+    tag_id bigint NOT NULL,
+    PRIMARY KEY (help_topic_id, tag_id),
+    CONSTRAINT fk_help_topic_tags_help_topic FOREIGN KEY (help_topic_id) REFERENCES help_topics (id),
+    CONSTRAINT fk_help_topic_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+);
+
+CREATE TABLE IF NOT EXISTS seed_generations (
+    id bigserial PRIMARY KEY,
+    generation bigint NOT NULL DEFAULT 0,
+    updated_at timestamptz
+);
@@ -0,0 +1,19 @@
+-- Levenshtein distance for fuzzy quickstart search. Installed here so the API
+-- never has to issue DDL at startup.
+--
+-- The extension is only created when it is missing: CREATE EXTENSION IF NOT
+-- EXISTS still runs DDL, which breaks the logical replication used by RDS
+-- blue/green deployments. A role that may not create extensions gets a notice
+-- instead of a failed migration; the API then finds no fuzzystrmatch in
+-- pg_extension and fuzzy search falls back to ILIKE until an administrator
+-- installs it.
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'fuzzystrmatch') THEN
+		CREATE EXTENSION fuzzystrmatch;
+	END IF;
+EXCEPTION
+	WHEN insufficient_privilege THEN
+		RAISE NOTICE 'fuzzystrmatch not installed (%); fuzzy search falls back to ILIKE', SQLERRM;
+END
+$$;
@@ -0,0 +1,2 @@
+ALTER TABLE seed_generations DROP COLUMN IF EXISTS revision;
+ALTER TABLE seed_generations DROP COLUMN IF EXISTS source;
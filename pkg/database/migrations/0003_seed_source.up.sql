@@ -0,0 +1,3 @@
+-- Where the content of the current seed generation came from.
+ALTER TABLE seed_generations ADD COLUMN IF NOT EXISTS source text;
+ALTER TABLE seed_generations ADD COLUMN IF NOT EXISTS revision text;
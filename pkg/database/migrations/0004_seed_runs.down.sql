@@ -0,0 +1,3 @@
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS source_revision;
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS source_file;
+DROP TABLE IF EXISTS seed_runs;
@@ -0,0 +1,20 @@
+-- History of content seeding runs and the file each quickstart came from.
+CREATE TABLE IF NOT EXISTS seed_runs (
+    id bigserial PRIMARY KEY,
+    started_at timestamptz NOT NULL,
+    finished_at timestamptz NOT NULL,
+    status text NOT NULL,
+    source text,
+    revision text,
+    created bigint NOT NULL DEFAULT 0,
+    updated bigint NOT NULL DEFAULT 0,
+    deleted bigint NOT NULL DEFAULT 0,
+    quickstart_errors bigint NOT NULL DEFAULT 0,
+    help_topic_errors bigint NOT NULL DEFAULT 0,
+    errors JSONB,
+    error text
+);
+CREATE INDEX IF NOT EXISTS idx_seed_runs_started_at ON seed_runs (started_at);
+
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS source_file text;
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS source_revision text;
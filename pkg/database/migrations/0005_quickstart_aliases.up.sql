@@ -0,0 +1,7 @@
+-- Previous names of renamed quickstarts, so lookups by an old name resolve to
+-- the current one.
+CREATE TABLE IF NOT EXISTS quickstart_aliases (
+    name text PRIMARY KEY,
+    quickstart_name text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_quickstart_aliases_quickstart_name ON quickstart_aliases (quickstart_name);
@@ -0,0 +1,8 @@
+ALTER TABLE help_topics DROP COLUMN IF EXISTS replacement;
+ALTER TABLE help_topics DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE help_topics DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE help_topics DROP COLUMN IF EXISTS status;
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS replacement;
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE quickstarts DROP COLUMN IF EXISTS status;
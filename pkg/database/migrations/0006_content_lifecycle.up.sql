@@ -0,0 +1,11 @@
+-- Lifecycle fields from the content metadata: drafts, publishing windows and
+-- deprecation.
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'published';
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS unpublish_at timestamptz;
+ALTER TABLE quickstarts ADD COLUMN IF NOT EXISTS replacement text;
+
+ALTER TABLE help_topics ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'published';
+ALTER TABLE help_topics ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE help_topics ADD COLUMN IF NOT EXISTS unpublish_at timestamptz;
+ALTER TABLE help_topics ADD COLUMN IF NOT EXISTS replacement text;
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	p "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// configurePool applies the connection pool limits from cfg to sqlDB.
+func configurePool(sqlDB *sql.DB, cfg *config.QuickstartsConfig) {
+	sqlDB.SetMaxOpenConns(cfg.DbMaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DbMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.DbConnMaxLifetime)
+}
+
+// registerPoolMetrics exports the sql.DBStats of sqlDB as the standard
+// go_sql_* metrics, labeled with name.
+func registerPoolMetrics(name string, sqlDB *sql.DB) {
+	if err := p.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		logrus.Warnf("Failed to register connection pool metrics for %s: %s", name, err.Error())
+	}
+}
+
+// withStatementTimeout adds a statement_timeout runtime parameter to a
+// PostgreSQL DSN in either URL or keyword/value form. pgx sends unknown DSN
+// settings to the server, so every connection in the pool gets the timeout.
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dsn
+	}
+	ms := strconv.FormatInt(timeout.Milliseconds(), 10)
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if u, err := url.Parse(dsn); err == nil {
+			q := u.Query()
+			q.Set("statement_timeout", ms)
+			u.RawQuery = q.Encode()
+			return u.String()
+		}
+	}
+	return fmt.Sprintf("%s statement_timeout=%s", dsn, ms)
+}
+
+// InitReadReplicas routes reads on DB to the read replicas configured in
+// DB_READ_REPLICA_DSNS. Writes, transactions and queries marked with
+// dbresolver.Write keep using the primary. It is a no-op without replicas.
+//
+// Only the API calls this: seeding and migrations read what they have just
+// written, which a lagging replica would not return.
+func InitReadReplicas() error {
+	cfg := config.Get()
+	if len(cfg.DbReadReplicaDSNs) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, len(cfg.DbReadReplicaDSNs))
+	for i, dsn := range cfg.DbReadReplicaDSNs {
+		// The postgres driver registers pgx with database/sql.
+		sqlDB, err := sql.Open("pgx", withStatementTimeout(dsn, cfg.DbStatementTimeout))
+		if err != nil {
+			return fmt.Errorf("read replica %d: %w", i, err)
+		}
+		configurePool(sqlDB, cfg)
+		registerPoolMetrics(fmt.Sprintf("replica-%d", i), sqlDB)
+		replicas[i] = postgres.New(postgres.Config{Conn: sqlDB})
+	}
+
+	if err := UseReadReplicas(DB, replicas...); err != nil {
+		return err
+	}
+	logrus.Infof("Routing catalog reads to %d read replica(s)", len(replicas))
+	return nil
+}
+
+// UseReadReplicas registers replicas with db so that queries go to a randomly
+// chosen replica and writes to db's own connection.
+func UseReadReplicas(db *gorm.DB, replicas ...gorm.Dialector) error {
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}))
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsn     string
+		timeout time.Duration
+		want    string
+	}{
+		{"disabled", "host=db user=u", 0, "host=db user=u"},
+		{"keyword/value", "host=db user=u", 30 * time.Second, "host=db user=u statement_timeout=30000"},
+		{"url", "postgres://u:p@db:5432/quickstarts?sslmode=disable", 1500 * time.Millisecond, "postgres://u:p@db:5432/quickstarts?sslmode=disable&statement_timeout=1500"},
+		{"postgresql url", "postgresql://db/quickstarts", time.Second, "postgresql://db/quickstarts?statement_timeout=1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, withStatementTimeout(tt.dsn, tt.timeout))
+		})
+	}
+}
+
+func TestPoolMetrics(t *testing.T) {
+	w := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `go_sql_max_open_connections{db_name="primary"} 20`)
+	assert.Contains(t, w.Body.String(), `go_sql_open_connections{db_name="primary"}`)
+}
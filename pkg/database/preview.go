@@ -0,0 +1,69 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/ghodss/yaml"
+)
+
+// Preview builds the content item that seeding would store for the metadata
+// file at loc, without a database: a models.Quickstart for a quickstart, or
+// the []models.HelpTopic of a help topic group, tagged with the tags of the
+// metadata file and the kind tag seeding adds.
+func Preview(loc string) (interface{}, error) {
+	template, err := readMetadata(loc)
+	if err != nil {
+		return nil, err
+	}
+	lifecycle, err := template.Lifecycle()
+	if err != nil {
+		return nil, err
+	}
+	tags := []models.Tag{{Type: models.ContentKind, Value: "quickstart"}}
+	if template.Kind == "HelpTopic" {
+		tags[0].Value = "helptopic"
+	}
+	for _, tag := range template.Tags {
+		tags = append(tags, models.Tag{Type: models.TagType(tag.Kind), Value: tag.Value})
+	}
+
+	switch template.Kind {
+	case "QuickStarts":
+		content, err := addTags(template)
+		if err != nil {
+			return nil, err
+		}
+		return models.Quickstart{Name: template.Name, Content: content, Tags: tags, SourceFile: template.ContentPath, PreviousNames: template.PreviousNames, Lifecycle: lifecycle}, nil
+	case "HelpTopic":
+		yamlfile, err := ioutil.ReadFile(template.ContentPath)
+		if err != nil {
+			return nil, err
+		}
+		jsonContent, err := yaml.YAMLToJSON(yamlfile)
+		if err != nil {
+			return nil, err
+		}
+		var topics []map[string]interface{}
+		if err := json.Unmarshal(jsonContent, &topics); err != nil {
+			return nil, err
+		}
+		helpTopics := make([]models.HelpTopic, 0, len(topics))
+		for i, topic := range topics {
+			name, _ := topic["name"].(string)
+			if name == "" {
+				return nil, fmt.Errorf("help topic %d has no name", i)
+			}
+			content, err := json.Marshal(topic)
+			if err != nil {
+				return nil, err
+			}
+			helpTopics = append(helpTopics, models.HelpTopic{GroupName: template.Name, Name: name, Content: content, Tags: tags, Lifecycle: lifecycle})
+		}
+		return helpTopics, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown kind %q", loc, template.Kind)
+	}
+}
@@ -4,8 +4,37 @@ import (
 	"fmt"
 
 	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/gorm"
 )
 
+// schemaModels are the models whose tables the migrations create.
+var schemaModels = []interface{}{
+	&models.Tag{},
+	&models.Quickstart{},
+	&models.QuickstartProgress{},
+	&models.HelpTopic{},
+	&models.FavoriteQuickstart{},
+	&models.SeedGeneration{},
+	&models.SeedRun{},
+	&models.QuickstartAlias{},
+}
+
+// MigrateTestSchema builds the schema tests run against. On PostgreSQL it
+// applies the embedded migrations, so tests see the schema production gets;
+// the migrations are PostgreSQL only, so SQLite gets AutoMigrate instead.
+func MigrateTestSchema(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return db.AutoMigrate(schemaModels...)
+	}
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	_, err = MigrateUp(db, migrations)
+	return err
+}
+
 // CleanTestTables truncates all model tables and resets serial sequences.
 // Only runs in test mode against PostgreSQL (SQLite tests use a fresh file each run).
 func CleanTestTables() error {
@@ -20,6 +49,9 @@ func CleanTestTables() error {
 		"tags",
 		"help_topics",
 		"quickstarts",
+		"seed_generations",
+		"seed_runs",
+		"quickstart_aliases",
 	}
 	for _, table := range tables {
 		if err := DB.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)).Error; err != nil {
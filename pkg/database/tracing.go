@@ -0,0 +1,63 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const querySpanKey = "quickstarts:query_span"
+
+// registerTracingCallbacks wraps every statement GORM issues in a client span,
+// parented to the context passed with DB.WithContext.
+func registerTracingCallbacks(db *gorm.DB) error {
+	system := semconv.DBSystemNamePostgreSQL
+	if db.Dialector.Name() == "sqlite" {
+		system = semconv.DBSystemNameSQLite
+	}
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			_, span := tracing.Tracer().Start(tx.Statement.Context, "db."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(system, semconv.DBOperationName(operation)),
+			)
+			tx.InstanceSet(querySpanKey, span)
+		}
+	}
+	after := func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(querySpanKey)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		if tx.Statement.Table != "" {
+			span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+		}
+		span.SetAttributes(semconv.DBQueryText(tx.Statement.SQL.String()))
+		err := tx.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		tracing.EndSpan(span, err)
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", after),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", after),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	)
+}
@@ -20,6 +20,7 @@ type GitServiceConfig struct {
 	QuickstartsDirPath string
 	PSKToken           string
 	GPGPrivateKey      string
+	TracesExporter     string
 }
 
 var cfg *GitServiceConfig
@@ -38,6 +39,7 @@ func Init() {
 		QuickstartsDirPath: getEnvOrDefault("QUICKSTARTS_DIR_PATH", "/docs/quickstarts/"),
 		PSKToken:           os.Getenv("PSK_TOKEN"),
 		GPGPrivateKey:      os.Getenv("GPG_PRIVATE_KEY"),
+		TracesExporter:     getEnvOrDefault("OTEL_TRACES_EXPORTER", "none"),
 	}
 
 	if clowder.IsClowderEnabled() {
@@ -147,6 +147,106 @@ func InitRepo(repoURL, repoPath, token, baseBranch, forkRepoURL, forkToken, gpgK
 	return mgr, nil
 }
 
+// CheckoutRef clones repoURL into dir and checks out ref, which may be a
+// branch, a tag or a commit SHA. It returns the SHA of the checked out commit.
+func CheckoutRef(repoURL, ref, token, dir string) (string, error) {
+	if err := validateRepoURL(repoURL); err != nil {
+		return "", err
+	}
+
+	var auth *http.BasicAuth
+	if token != "" {
+		auth = &http.BasicAuth{Username: "git", Password: token}
+	}
+
+	// Branches and tags are advertised by the remote, so only their tip is
+	// fetched. A commit SHA can be anywhere in history and needs a full clone.
+	name, err := remoteRefName(repoURL, ref, auth)
+	if err != nil {
+		return "", err
+	}
+	opts := &git.CloneOptions{URL: repoURL}
+	if auth != nil {
+		opts.Auth = auth
+	}
+	if name != "" {
+		opts.ReferenceName = name
+		opts.SingleBranch = true
+		opts.Depth = 1
+		opts.Tags = git.NoTags
+	} else {
+		opts.NoCheckout = true
+	}
+	repo, err := git.PlainClone(dir, false, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to clone %s: %w", repoURL, err)
+	}
+
+	var hash *plumbing.Hash
+	if name != "" {
+		head, err := repo.Head()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s in %s: %w", ref, repoURL, err)
+		}
+		h := head.Hash()
+		hash = &h
+	} else {
+		hash, err = repo.ResolveRevision(plumbing.Revision(ref))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s in %s: %w", ref, repoURL, err)
+		}
+		w, err := repo.Worktree()
+		if err != nil {
+			return "", fmt.Errorf("failed to get worktree: %w", err)
+		}
+		if err := w.Checkout(&git.CheckoutOptions{Hash: *hash, Force: true}); err != nil {
+			return "", fmt.Errorf("failed to checkout %s: %w", ref, err)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"url": repoURL, "ref": ref, "sha": hash.String()}).Info("Checked out content revision")
+	return hash.String(), nil
+}
+
+// remoteRefName returns the branch or tag reference ref names on the remote,
+// preferring branches, or "" when ref is neither.
+func remoteRefName(repoURL, ref string, auth *http.BasicAuth) (plumbing.ReferenceName, error) {
+	remote := git.NewRemote(nil, &config.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
+	opts := &git.ListOptions{}
+	if auth != nil {
+		opts.Auth = auth
+	}
+	refs, err := remote.List(opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list references of %s: %w", repoURL, err)
+	}
+	branch := plumbing.NewBranchReferenceName(ref)
+	tag := plumbing.NewTagReferenceName(ref)
+	var name plumbing.ReferenceName
+	for _, r := range refs {
+		switch r.Name() {
+		case branch:
+			return branch, nil
+		case tag:
+			name = tag
+		}
+	}
+	return name, nil
+}
+
+// HeadRevision returns the SHA of HEAD in the repository containing dir.
+func HeadRevision(dir string) (string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
 func (m *RepoManager) PullLatest() error {
 	w, err := m.Repo.Worktree()
 	if err != nil {
@@ -491,3 +491,71 @@ func TestPushBranch_ToForkRemote(t *testing.T) {
 	_, err = os.Stat(filepath.Join(verify, "fork-pushed.txt"))
 	assert.NoError(t, err)
 }
+
+func TestCheckoutRef(t *testing.T) {
+	bare := createBareRepo(t)
+	bareRepo, err := gogit.PlainOpen(bare)
+	require.NoError(t, err)
+	first, err := bareRepo.Head()
+	require.NoError(t, err)
+
+	// Add a second commit so a shallow clone has history to leave out.
+	work := t.TempDir()
+	repo, err := gogit.PlainClone(work, false, &gogit.CloneOptions{URL: bare})
+	require.NoError(t, err)
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(work, "README.md"), []byte("second"), 0644))
+	_, err = w.Add("README.md")
+	require.NoError(t, err)
+	second, err := w.Commit("second commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Push(&gogit.PushOptions{}))
+	_, err = bareRepo.CreateTag("v1", second, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		ref     string
+		sha     string
+		content string
+		shallow bool
+	}{
+		{"master", second.String(), "second", true},
+		{"v1", second.String(), "second", true},
+		{first.Hash().String(), first.Hash().String(), "init", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "content")
+			sha, err := CheckoutRef(bare, tt.ref, "", dir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.sha, sha)
+
+			data, err := os.ReadFile(filepath.Join(dir, "README.md"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(data))
+
+			rev, err := HeadRevision(dir)
+			require.NoError(t, err)
+			assert.Equal(t, sha, rev)
+
+			cloned, err := gogit.PlainOpen(dir)
+			require.NoError(t, err)
+			shallow, err := cloned.Storer.Shallow()
+			require.NoError(t, err)
+			assert.Equal(t, tt.shallow, len(shallow) > 0)
+		})
+	}
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := CheckoutRef(bare, "missing", "", filepath.Join(t.TempDir(), "content"))
+		assert.ErrorContains(t, err, "failed to resolve missing")
+	})
+
+	t.Run("unsupported URL", func(t *testing.T) {
+		_, err := CheckoutRef("https://example.com/repo.git", "main", "", t.TempDir())
+		assert.Error(t, err)
+	})
+}
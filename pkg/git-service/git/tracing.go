@@ -0,0 +1,106 @@
+package git
+
+import (
+	"context"
+
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedRepo wraps RepoOperations so each call is recorded as a child span
+// of the request that triggered it.
+type tracedRepo struct {
+	ctx  context.Context
+	repo RepoOperations
+}
+
+// Traced returns repo with every operation traced under ctx.
+func Traced(ctx context.Context, repo RepoOperations) RepoOperations {
+	return &tracedRepo{ctx: ctx, repo: repo}
+}
+
+func (t *tracedRepo) start(name string, attrs ...attribute.KeyValue) func(error) {
+	_, span := tracing.StartSpan(t.ctx, "git."+name, attrs...)
+	return func(err error) { tracing.EndSpan(span, err) }
+}
+
+func (t *tracedRepo) PullLatest() error {
+	end := t.start("PullLatest")
+	err := t.repo.PullLatest()
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) CreateBranch(name string) error {
+	end := t.start("CreateBranch", attribute.String("git.branch", name))
+	err := t.repo.CreateBranch(name)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) CheckoutExistingBranch(name string) error {
+	end := t.start("CheckoutExistingBranch", attribute.String("git.branch", name))
+	err := t.repo.CheckoutExistingBranch(name)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) WriteFiles(dir string, files []File) error {
+	end := t.start("WriteFiles", attribute.String("git.dir", dir), attribute.Int("git.files", len(files)))
+	err := t.repo.WriteFiles(dir, files)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) CommitChanges(message, authorName, authorEmail, dir string, files []File) (string, error) {
+	end := t.start("CommitChanges", attribute.String("git.dir", dir), attribute.Int("git.files", len(files)))
+	sha, err := t.repo.CommitChanges(message, authorName, authorEmail, dir, files)
+	end(err)
+	return sha, err
+}
+
+func (t *tracedRepo) PushBranch(branch string) error {
+	end := t.start("PushBranch", attribute.String("git.branch", branch))
+	err := t.repo.PushBranch(branch)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) PushBranchForce(branch string) error {
+	end := t.start("PushBranchForce", attribute.String("git.branch", branch))
+	err := t.repo.PushBranchForce(branch)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) Cleanup(branch string) error {
+	end := t.start("Cleanup", attribute.String("git.branch", branch))
+	err := t.repo.Cleanup(branch)
+	end(err)
+	return err
+}
+
+func (t *tracedRepo) GetBaseBranch() string {
+	return t.repo.GetBaseBranch()
+}
+
+func (t *tracedRepo) ListDirectories(basePath string) ([]string, error) {
+	end := t.start("ListDirectories", attribute.String("git.dir", basePath))
+	dirs, err := t.repo.ListDirectories(basePath)
+	end(err)
+	return dirs, err
+}
+
+func (t *tracedRepo) ListFiles(basePath string) ([]string, error) {
+	end := t.start("ListFiles", attribute.String("git.dir", basePath))
+	files, err := t.repo.ListFiles(basePath)
+	end(err)
+	return files, err
+}
+
+func (t *tracedRepo) ReadFile(path string) (string, error) {
+	end := t.start("ReadFile", attribute.String("git.path", path))
+	content, err := t.repo.ReadFile(path)
+	end(err)
+	return content, err
+}
@@ -3,9 +3,11 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/RedHatInsights/quickstarts/pkg/tracing"
 	"github.com/google/go-github/v66/github"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
@@ -31,7 +33,9 @@ func NewClient(token, repoURL, forkOwner string) (*Client, error) {
 	}
 
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(context.Background(), ts)
+	// The oauth2 client wraps this one, so GitHub API calls are traced too.
+	base := &http.Client{Transport: tracing.Transport(nil)}
+	tc := oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, base), ts)
 
 	return &Client{
 		gh:        github.NewClient(tc),
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"path/filepath"
 
+	gitops "github.com/RedHatInsights/quickstarts/pkg/git-service/git"
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -29,17 +30,18 @@ func (h *Handler) ListQuickstarts(w http.ResponseWriter, r *http.Request) {
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	repo := gitops.Traced(r.Context(), h.repoMgr)
 
-	if err := h.repoMgr.PullLatest(); err != nil {
+	if err := repo.PullLatest(); err != nil {
 		logrus.WithError(err).Error("Failed to pull latest")
-		writeError(w, http.StatusInternalServerError, "failed to pull latest changes")
+		writeError(w, r, http.StatusInternalServerError, "failed to pull latest changes")
 		return
 	}
 
-	dirs, err := h.repoMgr.ListDirectories(h.quickstartsDirPath)
+	dirs, err := repo.ListDirectories(h.quickstartsDirPath)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to list quickstart directories")
-		writeError(w, http.StatusInternalServerError, "failed to list quickstarts")
+		writeError(w, r, http.StatusInternalServerError, "failed to list quickstarts")
 		return
 	}
 
@@ -48,10 +50,10 @@ func (h *Handler) ListQuickstarts(w http.ResponseWriter, r *http.Request) {
 		entry := QuickstartEntry{Name: dir}
 
 		contentPath := filepath.Join(h.quickstartsDirPath, dir, dir+".yml")
-		content, err := h.repoMgr.ReadFile(contentPath)
+		content, err := repo.ReadFile(contentPath)
 		if err != nil {
 			contentPath = filepath.Join(h.quickstartsDirPath, dir, dir+".yaml")
-			content, err = h.repoMgr.ReadFile(contentPath)
+			content, err = repo.ReadFile(contentPath)
 		}
 		if err == nil {
 			entry.DisplayName = extractDisplayName(content)
@@ -72,43 +74,44 @@ func (h *Handler) GetQuickstartContent(w http.ResponseWriter, r *http.Request) {
 
 	name := chi.URLParam(r, "name")
 	if name == "" {
-		writeError(w, http.StatusBadRequest, "quickstart name is required")
+		writeError(w, r, http.StatusBadRequest, "quickstart name is required")
 		return
 	}
 	if containsTraversal(name) {
-		writeError(w, http.StatusBadRequest, "name contains invalid path segment")
+		writeError(w, r, http.StatusBadRequest, "name contains invalid path segment")
 		return
 	}
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	repo := gitops.Traced(r.Context(), h.repoMgr)
 
-	if err := h.repoMgr.PullLatest(); err != nil {
+	if err := repo.PullLatest(); err != nil {
 		logrus.WithError(err).Error("Failed to pull latest")
-		writeError(w, http.StatusInternalServerError, "failed to pull latest changes")
+		writeError(w, r, http.StatusInternalServerError, "failed to pull latest changes")
 		return
 	}
 
 	dirPath := filepath.Join(h.quickstartsDirPath, name)
-	fileNames, err := h.repoMgr.ListFiles(dirPath)
+	fileNames, err := repo.ListFiles(dirPath)
 	if err != nil {
-		writeError(w, http.StatusNotFound, "quickstart not found")
+		writeError(w, r, http.StatusNotFound, "quickstart not found")
 		return
 	}
 
 	files := make([]File, 0, len(fileNames))
 	for _, fileName := range fileNames {
-		content, err := h.repoMgr.ReadFile(filepath.Join(dirPath, fileName))
+		content, err := repo.ReadFile(filepath.Join(dirPath, fileName))
 		if err != nil {
 			logrus.WithError(err).WithField("file", fileName).Error("Failed to read file")
-			writeError(w, http.StatusInternalServerError, "failed to read quickstart files")
+			writeError(w, r, http.StatusInternalServerError, "failed to read quickstart files")
 			return
 		}
 		files = append(files, File{Name: fileName, Content: content})
 	}
 
 	if len(files) == 0 {
-		writeError(w, http.StatusNotFound, "no files found for quickstart")
+		writeError(w, r, http.StatusNotFound, "no files found for quickstart")
 		return
 	}
 
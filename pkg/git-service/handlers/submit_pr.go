@@ -10,6 +10,7 @@ import (
 
 	gitops "github.com/RedHatInsights/quickstarts/pkg/git-service/git"
 	ghclient "github.com/RedHatInsights/quickstarts/pkg/git-service/github"
+	"github.com/RedHatInsights/quickstarts/pkg/git-service/problem"
 	"github.com/sirupsen/logrus"
 )
 
@@ -67,43 +68,44 @@ func (h *Handler) SubmitPR(w http.ResponseWriter, r *http.Request) {
 	var req SubmitPRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		if err.Error() == "http: request body too large" {
-			writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			writeError(w, r, http.StatusRequestEntityTooLarge, "request body too large")
 			return
 		}
-		writeError(w, http.StatusBadRequest, "invalid request body")
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if err := validateRequest(&req); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	repo := gitops.Traced(r.Context(), h.repoMgr)
 
-	if err := h.repoMgr.PullLatest(); err != nil {
+	if err := repo.PullLatest(); err != nil {
 		logrus.WithError(err).Error("Failed to pull latest")
-		writeError(w, http.StatusInternalServerError, "failed to pull latest changes")
+		writeError(w, r, http.StatusInternalServerError, "failed to pull latest changes")
 		return
 	}
 
 	branchExisted := false
 	if req.Metadata.IsUpdate {
-		if err := h.repoMgr.CheckoutExistingBranch(req.Metadata.BranchName); err != nil {
+		if err := repo.CheckoutExistingBranch(req.Metadata.BranchName); err != nil {
 			logrus.WithError(err).Info("Existing branch not found, creating new branch for update")
-			if err := h.repoMgr.CreateBranch(req.Metadata.BranchName); err != nil {
+			if err := repo.CreateBranch(req.Metadata.BranchName); err != nil {
 				logrus.WithError(err).Error("Failed to create branch")
-				writeError(w, http.StatusInternalServerError, "failed to create branch")
+				writeError(w, r, http.StatusInternalServerError, "failed to create branch")
 				return
 			}
 		} else {
 			branchExisted = true
 		}
 	} else {
-		if err := h.repoMgr.CreateBranch(req.Metadata.BranchName); err != nil {
+		if err := repo.CreateBranch(req.Metadata.BranchName); err != nil {
 			logrus.WithError(err).Error("Failed to create branch")
-			writeError(w, http.StatusInternalServerError, "failed to create branch")
+			writeError(w, r, http.StatusInternalServerError, "failed to create branch")
 			return
 		}
 	}
@@ -122,40 +124,40 @@ func (h *Handler) SubmitPR(w http.ResponseWriter, r *http.Request) {
 		gitFiles[i] = gitops.File{Name: f.Name, Content: f.Content}
 	}
 
-	if err := h.repoMgr.WriteFiles(dir, gitFiles); err != nil {
+	if err := repo.WriteFiles(dir, gitFiles); err != nil {
 		logrus.WithError(err).Error("Failed to write files")
-		h.cleanup(req.Metadata.BranchName)
-		writeError(w, http.StatusInternalServerError, "failed to write files")
+		h.cleanup(repo, req.Metadata.BranchName)
+		writeError(w, r, http.StatusInternalServerError, "failed to write files")
 		return
 	}
 
-	sha, err := h.repoMgr.CommitChanges(req.Metadata.CommitMessage, "nacho-bot", "crc-nachobot@redhat.com", dir, gitFiles)
+	sha, err := repo.CommitChanges(req.Metadata.CommitMessage, "nacho-bot", "crc-nachobot@redhat.com", dir, gitFiles)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to commit")
-		h.cleanup(req.Metadata.BranchName)
-		writeError(w, http.StatusInternalServerError, "failed to commit changes")
+		h.cleanup(repo, req.Metadata.BranchName)
+		writeError(w, r, http.StatusInternalServerError, "failed to commit changes")
 		return
 	}
 
 	if req.Metadata.IsUpdate && branchExisted {
-		if err := h.repoMgr.PushBranchForce(req.Metadata.BranchName); err != nil {
+		if err := repo.PushBranchForce(req.Metadata.BranchName); err != nil {
 			logrus.WithError(err).Error("Failed to push update")
-			h.cleanup(req.Metadata.BranchName)
-			writeError(w, http.StatusInternalServerError, "failed to push branch")
+			h.cleanup(repo, req.Metadata.BranchName)
+			writeError(w, r, http.StatusInternalServerError, "failed to push branch")
 			return
 		}
 
-		h.cleanup(req.Metadata.BranchName)
+		h.cleanup(repo, req.Metadata.BranchName)
 		json.NewEncoder(w).Encode(SubmitPRResponse{
 			BranchName: req.Metadata.BranchName,
 			CommitSHA:  sha,
 			Status:     "updated",
 		})
 	} else {
-		if err := h.repoMgr.PushBranch(req.Metadata.BranchName); err != nil {
+		if err := repo.PushBranch(req.Metadata.BranchName); err != nil {
 			logrus.WithError(err).Error("Failed to push")
-			h.cleanup(req.Metadata.BranchName)
-			writeError(w, http.StatusInternalServerError, "failed to push branch")
+			h.cleanup(repo, req.Metadata.BranchName)
+			writeError(w, r, http.StatusInternalServerError, "failed to push branch")
 			return
 		}
 
@@ -169,17 +171,17 @@ func (h *Handler) SubmitPR(w http.ResponseWriter, r *http.Request) {
 			req.Metadata.PRTitle,
 			body,
 			req.Metadata.BranchName,
-			h.repoMgr.GetBaseBranch(),
+			repo.GetBaseBranch(),
 		)
 		if err != nil {
 			logrus.WithError(err).Error("Failed to create PR")
-			h.cleanup(req.Metadata.BranchName)
-			writeError(w, http.StatusInternalServerError, "failed to create pull request")
+			h.cleanup(repo, req.Metadata.BranchName)
+			writeError(w, r, http.StatusInternalServerError, "failed to create pull request")
 			return
 		}
 
 		h.gitHubClient.AssignReviewers(r.Context(), prNumber, h.reviewersTeam)
-		h.cleanup(req.Metadata.BranchName)
+		h.cleanup(repo, req.Metadata.BranchName)
 
 		json.NewEncoder(w).Encode(SubmitPRResponse{
 			PRURL:      prURL,
@@ -190,8 +192,8 @@ func (h *Handler) SubmitPR(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) cleanup(branch string) {
-	if err := h.repoMgr.Cleanup(branch); err != nil {
+func (h *Handler) cleanup(repo gitops.RepoOperations, branch string) {
+	if err := repo.Cleanup(branch); err != nil {
 		logrus.WithError(err).Warn("Branch cleanup failed")
 	}
 }
@@ -242,10 +244,6 @@ func containsTraversal(path string) bool {
 	return false
 }
 
-func writeError(w http.ResponseWriter, status int, msg string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{
-		"status": "error",
-		"msg":    msg,
-	})
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	problem.Write(w, r, status, msg)
 }
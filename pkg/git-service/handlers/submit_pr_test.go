@@ -178,9 +178,11 @@ func TestSubmitPR_InvalidJSON(t *testing.T) {
 	handler.SubmitPR(rec, req)
 
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	var resp map[string]string
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	var resp map[string]interface{}
 	json.NewDecoder(rec.Body).Decode(&resp)
-	assert.Equal(t, "invalid request body", resp["msg"])
+	assert.Equal(t, "invalid request body", resp["detail"])
+	assert.Equal(t, float64(http.StatusBadRequest), resp["status"])
 }
 
 func TestSubmitPR_MissingFields(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"crypto/subtle"
 	"net/http"
 
+	"github.com/RedHatInsights/quickstarts/pkg/git-service/problem"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,16 +21,12 @@ func PSKAuth(token string) func(http.Handler) http.Handler {
 
 			provided := r.Header.Get(PSKHeader)
 			if provided == "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"status":"error","msg":"missing authentication token"}`))
+				problem.Write(w, r, http.StatusUnauthorized, "missing authentication token")
 				return
 			}
 
 			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"status":"error","msg":"invalid authentication token"}`))
+				problem.Write(w, r, http.StatusUnauthorized, "invalid authentication token")
 				return
 			}
 
@@ -0,0 +1,45 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ContentType is the media type of RFC 7807 error responses.
+const ContentType = "application/problem+json"
+
+// RequestIDHeader is forwarded by the quickstarts API so errors can be
+// correlated with the originating request.
+const RequestIDHeader = "x-rh-insights-request-id"
+
+// Details is an RFC 7807 problem document.
+type Details struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// Write responds with a problem document for status. The instance is the
+// forwarded platform request id, or the local request id when the caller did
+// not send one.
+func Write(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	instance := r.Header.Get(RequestIDHeader)
+	if instance == "" {
+		instance = middleware.GetReqID(r.Context())
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Details{
+		Type:     "urn:quickstarts-git-service:problem:" + strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "-"),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	})
+}
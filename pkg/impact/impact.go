@@ -0,0 +1,348 @@
+// Package impact compares two versions of the content tree the way seeding
+// sees them, to show reviewers what a content change does to the catalog:
+// which items are added, removed or renamed, which filters items move in or
+// out of, and how many users' favorites and progress would be orphaned.
+package impact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+)
+
+// Item is a quickstart or help topic as seeding would store it.
+type Item struct {
+	Name          string
+	DisplayName   string
+	File          string   // Content file, relative to the content directory
+	Tags          []string // "kind=value", sorted, without the kind tag seeding adds
+	PreviousNames []string // Names a renamed quickstart lists in its metadata
+	content       string   // Canonical JSON of the seeded content
+}
+
+// Catalog is the content seeding would load from a content directory.
+type Catalog struct {
+	Quickstarts map[string]Item
+	HelpTopics  map[string]Item
+	Skipped     []string // Metadata files seeding would skip, with the reason
+}
+
+// Load reads every item under dir through the same code seeding uses.
+func Load(dir string) (*Catalog, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	c := &Catalog{Quickstarts: map[string]Item{}, HelpTopics: map[string]Item{}}
+	quickstartFiles, _ := filepath.Glob(filepath.Join(dir, "quickstarts", "*", "metadata.y*"))
+	topicFiles, _ := filepath.Glob(filepath.Join(dir, "help-topics", "*", "metadata.y*"))
+	for _, file := range append(quickstartFiles, topicFiles...) {
+		rel, _ := filepath.Rel(dir, file)
+		rel = filepath.ToSlash(rel)
+		loaded, err := database.Preview(file)
+		if err != nil {
+			c.Skipped = append(c.Skipped, fmt.Sprintf("%s: %s", rel, err))
+			continue
+		}
+		switch loaded := loaded.(type) {
+		case models.Quickstart:
+			item := newItem(loaded.Name, loaded.Content, loaded.Tags, "spec", "displayName")
+			item.File = filepath.ToSlash(filepath.Join(filepath.Dir(rel), filepath.Base(loaded.SourceFile)))
+			item.PreviousNames = loaded.PreviousNames
+			c.Quickstarts[item.Name] = item
+		case []models.HelpTopic:
+			for _, topic := range loaded {
+				item := newItem(topic.Name, topic.Content, topic.Tags, "title")
+				item.File = rel
+				c.HelpTopics[item.Name] = item
+			}
+		}
+	}
+	return c, nil
+}
+
+func newItem(name string, content []byte, tags []models.Tag, displayName ...string) Item {
+	item := Item{Name: name}
+	for _, tag := range tags {
+		if tag.Type != models.ContentKind {
+			item.Tags = append(item.Tags, string(tag.Type)+"="+tag.Value)
+		}
+	}
+	sort.Strings(item.Tags)
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err == nil {
+		// Marshalling a map sorts its keys, so formatting changes to the
+		// YAML do not count as content changes.
+		canonical, _ := json.Marshal(data)
+		item.content = string(canonical)
+		var value interface{} = data
+		for _, key := range displayName {
+			m, _ := value.(map[string]interface{})
+			value = m[key]
+		}
+		item.DisplayName, _ = value.(string)
+	}
+	return item
+}
+
+// Rename is a quickstart that is removed under one name and added under
+// another.
+type Rename struct {
+	From, To Item
+	Reason   string // What matched the two, such as "same display name"
+	// Aliased reports that To lists From's name under previousNames, so
+	// seeding moves favorites and progress to the new name.
+	Aliased bool
+}
+
+// Changes are the differences in one kind of item.
+type Changes struct {
+	Added   []Item
+	Removed []Item
+	Renamed []Rename
+	Changed []Item // Present in both versions with different content or tags
+}
+
+// Empty reports whether there are no changes.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Renamed) == 0 && len(c.Changed) == 0
+}
+
+// FilterChange lists the quickstarts that enter or leave a catalog filter
+// because their tags change. Added and removed quickstarts are not listed.
+type FilterChange struct {
+	Tag      string // "kind=value"
+	Label    string // As the filter is shown in the console
+	Entering []string
+	Leaving  []string
+}
+
+// Usage counts the users holding on to a quickstart.
+type Usage struct {
+	Favorites  int
+	InProgress int
+}
+
+// Report is the impact of replacing the base content with the head content.
+type Report struct {
+	Base, Head  string // Descriptions of the compared versions
+	Quickstarts Changes
+	HelpTopics  Changes
+	Filters     []FilterChange
+	Skipped     []string         // Head items seeding would skip
+	Users       map[string]Usage // By quickstart name; nil until CountUsers runs
+}
+
+// Compare returns the impact of replacing base with head.
+func Compare(base, head *Catalog) *Report {
+	r := &Report{
+		Quickstarts: diff(base.Quickstarts, head.Quickstarts),
+		HelpTopics:  diff(base.HelpTopics, head.HelpTopics),
+		Skipped:     head.Skipped,
+	}
+	r.Quickstarts.Renamed, r.Quickstarts.Added, r.Quickstarts.Removed = matchRenames(r.Quickstarts.Added, r.Quickstarts.Removed)
+
+	pairs := map[string]Item{}
+	for name := range head.Quickstarts {
+		if previous, ok := base.Quickstarts[name]; ok {
+			pairs[name] = previous
+		}
+	}
+	for _, rename := range r.Quickstarts.Renamed {
+		pairs[rename.To.Name] = rename.From
+	}
+	r.Filters = filterChanges(pairs, head.Quickstarts)
+	return r
+}
+
+func diff(base, head map[string]Item) Changes {
+	var c Changes
+	for _, name := range sortedNames(head) {
+		previous, ok := base[name]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, head[name])
+		case previous.content != head[name].content || !slices.Equal(previous.Tags, head[name].Tags):
+			c.Changed = append(c.Changed, head[name])
+		}
+	}
+	for _, name := range sortedNames(base) {
+		if _, ok := head[name]; !ok {
+			c.Removed = append(c.Removed, base[name])
+		}
+	}
+	return c
+}
+
+// matchRenames pairs removed and added items that are most likely the same
+// item under a new name: those whose new version lists the old name under
+// previousNames, then those in the same directory, then those with the same
+// display name.
+func matchRenames(added, removed []Item) (renames []Rename, unmatchedAdded, unmatchedRemoved []Item) {
+	renamedTo := map[string]int{}
+	for i, item := range added {
+		for _, previous := range item.PreviousNames {
+			renamedTo[previous] = i
+		}
+	}
+	aliased := map[int]bool{}
+	var rest []Item
+	for _, item := range removed {
+		i, ok := renamedTo[item.Name]
+		if !ok {
+			rest = append(rest, item)
+			continue
+		}
+		aliased[i] = true
+		renames = append(renames, Rename{From: item, To: added[i], Reason: "previous name", Aliased: true})
+	}
+	removed = rest
+	// An item renamed twice lists both old names, so it stays a candidate
+	// only when it matched nothing by previous name.
+	var remaining []Item
+	for i, item := range added {
+		if !aliased[i] {
+			remaining = append(remaining, item)
+		}
+	}
+	added = remaining
+
+	matchers := []struct {
+		reason string
+		key    func(Item) string
+	}{
+		{"same directory", func(i Item) string { return filepath.Dir(i.File) }},
+		{"same display name", func(i Item) string { return i.DisplayName }},
+	}
+	for _, m := range matchers {
+		byKey := map[string][]int{}
+		for i, item := range added {
+			if key := m.key(item); key != "" {
+				byKey[key] = append(byKey[key], i)
+			}
+		}
+		matched := map[int]bool{}
+		var rest []Item
+		for _, item := range removed {
+			candidates := byKey[m.key(item)]
+			// Only an unambiguous match is a rename.
+			if key := m.key(item); key == "" || len(candidates) != 1 || matched[candidates[0]] {
+				rest = append(rest, item)
+				continue
+			}
+			matched[candidates[0]] = true
+			renames = append(renames, Rename{From: item, To: added[candidates[0]], Reason: m.reason})
+		}
+		var remaining []Item
+		for i, item := range added {
+			if !matched[i] {
+				remaining = append(remaining, item)
+			}
+		}
+		added, removed = remaining, rest
+	}
+	sort.Slice(renames, func(i, j int) bool { return renames[i].From.Name < renames[j].From.Name })
+	return renames, added, removed
+}
+
+// filterChanges compares the tags of every head quickstart with those of the
+// base item in pairs.
+func filterChanges(pairs map[string]Item, head map[string]Item) []FilterChange {
+	changes := map[string]*FilterChange{}
+	change := func(tag string) *FilterChange {
+		if changes[tag] == nil {
+			changes[tag] = &FilterChange{Tag: tag, Label: filterLabel(tag)}
+		}
+		return changes[tag]
+	}
+	for _, name := range sortedNames(head) {
+		previous, ok := pairs[name]
+		if !ok {
+			continue
+		}
+		before, after := set(previous.Tags), set(head[name].Tags)
+		for _, tag := range head[name].Tags {
+			if !before[tag] {
+				change(tag).Entering = append(change(tag).Entering, name)
+			}
+		}
+		for _, tag := range previous.Tags {
+			if !after[tag] {
+				change(tag).Leaving = append(change(tag).Leaving, name)
+			}
+		}
+	}
+	var result []FilterChange
+	for _, c := range changes {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+	return result
+}
+
+// filterLabel returns the category and filter labels the console shows for a
+// tag, falling back to its kind and value.
+func filterLabel(tag string) string {
+	kind, value, _ := strings.Cut(tag, "=")
+	for _, category := range models.FrontendFilters.Categories {
+		if string(category.CategoryID) != kind {
+			continue
+		}
+		for _, group := range category.CategoryData {
+			for _, item := range group.Data {
+				if item.Id == value {
+					return category.CategoryName + ": " + item.FilterLabel
+				}
+			}
+		}
+		return category.CategoryName + ": " + value
+	}
+	return kind + ": " + value
+}
+
+// Orphaned returns the names of the base quickstarts that no longer exist in
+// head, removed or renamed without a previous name, whose favorites and
+// progress seeding would lose.
+func (r *Report) Orphaned() []string {
+	return r.gone(false)
+}
+
+// gone returns the names of the base quickstarts that no longer exist in
+// head, with or without those whose users seeding moves to the new name.
+func (r *Report) gone(aliased bool) []string {
+	var names []string
+	for _, item := range r.Quickstarts.Removed {
+		names = append(names, item.Name)
+	}
+	for _, rename := range r.Quickstarts.Renamed {
+		if aliased || !rename.Aliased {
+			names = append(names, rename.From.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedNames(items map[string]Item) []string {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func set(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, v := range values {
+		result[v] = true
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+package impact
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func writeQuickstart(t *testing.T, base, dir, name, displayName string, tags ...string) {
+	t.Helper()
+	metadata := "kind: QuickStarts\nname: " + name + "\ntags:\n"
+	for _, tag := range tags {
+		kind, value, _ := strings.Cut(tag, "=")
+		metadata += fmt.Sprintf("  - kind: %s\n    value: %s\n", kind, value)
+	}
+	files := map[string]string{
+		"metadata.yml": metadata,
+		name + ".yml":  fmt.Sprintf("metadata:\n  name: %s\nspec:\n  displayName: %s\n", name, displayName),
+	}
+	for file, content := range files {
+		path := filepath.Join(base, "quickstarts", dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func writeHelpTopics(t *testing.T, base, group string, names ...string) {
+	t.Helper()
+	content := ""
+	for _, name := range names {
+		content += fmt.Sprintf("- name: %s\n  title: %s\n  content: Text\n", name, name)
+	}
+	dir := filepath.Join(base, "help-topics", group)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.yml"), []byte("kind: HelpTopic\nname: "+group+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, group+".yml"), []byte(content), 0o644))
+}
+
+func testCatalogs(t *testing.T) (*Catalog, *Catalog) {
+	t.Helper()
+	base, head := t.TempDir(), t.TempDir()
+
+	writeQuickstart(t, base, "kept", "kept", "Kept", "bundle=insights", "content=documentation")
+	writeQuickstart(t, head, "kept", "kept", "Kept", "bundle=insights", "content=learningPath")
+	writeQuickstart(t, base, "same", "same", "Same", "bundle=insights")
+	writeQuickstart(t, head, "same", "same", "Same", "bundle=insights")
+	writeQuickstart(t, base, "gone", "gone", "Gone", "bundle=insights")
+	writeQuickstart(t, base, "old-name", "old-name", "Renamed", "bundle=rhel")
+	writeQuickstart(t, head, "new-name", "new-name", "Renamed", "bundle=rhel", "product-families=rhel")
+	writeQuickstart(t, base, "moved", "moved-old", "Moved before")
+	writeQuickstart(t, head, "moved", "moved-new", "Moved after")
+	writeQuickstart(t, head, "fresh", "fresh", "Fresh | new", "bundle=ansible")
+	writeQuickstart(t, base, "aliased", "aliased-old", "Aliased")
+	writeQuickstart(t, head, "aliased-elsewhere", "aliased-new", "Aliased anew")
+	metadata, err := os.OpenFile(filepath.Join(head, "quickstarts", "aliased-elsewhere", "metadata.yml"), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = metadata.WriteString("previousNames: [aliased-old]\n")
+	require.NoError(t, err)
+	require.NoError(t, metadata.Close())
+	writeHelpTopics(t, base, "group", "topic-a", "topic-b")
+	writeHelpTopics(t, head, "group", "topic-a", "topic-c")
+
+	broken := filepath.Join(head, "quickstarts", "broken")
+	require.NoError(t, os.MkdirAll(broken, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(broken, "metadata.yml"), []byte("kind: QuickStarts\nname: broken\n"), 0o644))
+
+	baseCatalog, err := Load(base)
+	require.NoError(t, err)
+	headCatalog, err := Load(head)
+	require.NoError(t, err)
+	return baseCatalog, headCatalog
+}
+
+func names(items []Item) []string {
+	var result []string
+	for _, item := range items {
+		result = append(result, item.Name)
+	}
+	return result
+}
+
+func TestCompare(t *testing.T) {
+	base, head := testCatalogs(t)
+	r := Compare(base, head)
+
+	assert.Equal(t, []string{"fresh"}, names(r.Quickstarts.Added))
+	assert.Equal(t, []string{"gone"}, names(r.Quickstarts.Removed))
+	assert.Equal(t, []string{"kept"}, names(r.Quickstarts.Changed))
+	require.Len(t, r.Quickstarts.Renamed, 3)
+	assert.Equal(t, Rename{From: base.Quickstarts["aliased-old"], To: head.Quickstarts["aliased-new"], Reason: "previous name", Aliased: true}, r.Quickstarts.Renamed[0])
+	assert.Equal(t, "moved-old", r.Quickstarts.Renamed[1].From.Name)
+	assert.Equal(t, "moved-new", r.Quickstarts.Renamed[1].To.Name)
+	assert.Equal(t, "same directory", r.Quickstarts.Renamed[1].Reason)
+	assert.Equal(t, "old-name", r.Quickstarts.Renamed[2].From.Name)
+	assert.Equal(t, "new-name", r.Quickstarts.Renamed[2].To.Name)
+	assert.Equal(t, "same display name", r.Quickstarts.Renamed[2].Reason)
+	assert.False(t, r.Quickstarts.Renamed[2].Aliased)
+
+	assert.Equal(t, []string{"topic-c"}, names(r.HelpTopics.Added))
+	assert.Equal(t, []string{"topic-b"}, names(r.HelpTopics.Removed))
+	assert.Empty(t, r.HelpTopics.Changed)
+
+	assert.Equal(t, []FilterChange{
+		{Tag: "content=documentation", Label: "Content type: Documentation", Leaving: []string{"kept"}},
+		{Tag: "content=learningPath", Label: "Content type: Learning path", Entering: []string{"kept"}},
+		{Tag: "product-families=rhel", Label: "Product families: RHEL (Red Hat Enterprise Linux)", Entering: []string{"new-name"}},
+	}, r.Filters)
+
+	require.Len(t, r.Skipped, 1)
+	assert.Contains(t, r.Skipped[0], "quickstarts/broken/metadata.yml")
+	assert.Equal(t, []string{"gone", "moved-old", "old-name"}, r.Orphaned())
+}
+
+func TestCountUsers(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "impact.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.FavoriteQuickstart{}, &models.QuickstartProgress{}))
+	require.NoError(t, db.Create([]models.FavoriteQuickstart{
+		{AccountId: "1", QuickstartName: "gone", Favorite: true},
+		{AccountId: "2", QuickstartName: "gone", Favorite: true},
+		{AccountId: "3", QuickstartName: "gone", Favorite: false},
+		{AccountId: "1", QuickstartName: "old-name", Favorite: true},
+		{AccountId: "1", QuickstartName: "kept", Favorite: true},
+		{AccountId: "1", QuickstartName: "aliased-old", Favorite: true},
+	}).Error)
+	require.NoError(t, db.Create([]models.QuickstartProgress{
+		{AccountId: 1, QuickstartName: "old-name"},
+		{AccountId: 2, QuickstartName: "old-name"},
+		{AccountId: 1, QuickstartName: "kept"},
+	}).Error)
+
+	base, head := testCatalogs(t)
+	r := Compare(base, head)
+	require.NoError(t, r.CountUsers(db))
+	assert.Equal(t, map[string]Usage{
+		"gone":        {Favorites: 2},
+		"old-name":    {Favorites: 1, InProgress: 2},
+		"aliased-old": {Favorites: 1},
+	}, r.Users, "renames with a previous name are counted but not orphaned")
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	base, head := testCatalogs(t)
+	r := Compare(base, head)
+	r.Base, r.Head = "main (0123456789ab)", "docs"
+	r.Users = map[string]Usage{"gone": {Favorites: 2}, "old-name": {Favorites: 1, InProgress: 2}, "aliased-old": {Favorites: 4}}
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteMarkdown(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "Comparing `main (0123456789ab)` with `docs`.")
+	assert.Contains(t, out, "| Quickstarts | 1 | 1 | 3 | 1 |")
+	assert.Contains(t, out, "| Help topics | 1 | 1 | 0 | 0 |")
+	assert.Contains(t, out, "**3 favorites and 2 in-progress quickstarts would be orphaned.**")
+	assert.Contains(t, out, "| `gone` | Gone | 2 | 0 |")
+	assert.Contains(t, out, "| `old-name` | `new-name` | same display name | orphaned | 1 | 2 |")
+	assert.Contains(t, out, "| `aliased-old` | `aliased-new` | previous name | moved | 4 | 0 |")
+	assert.Contains(t, out, "| `fresh` | Fresh \\| new | `bundle=ansible` |")
+	assert.Contains(t, out, "| Content type: Learning path | `content=learningPath` | `kept` |  |")
+	assert.Contains(t, out, "- Removed: `topic-b`")
+	assert.Contains(t, out, "### Skipped by seeding")
+
+	buf.Reset()
+	same := Compare(base, base)
+	require.NoError(t, same.WriteMarkdown(&buf))
+	assert.Equal(t, "## Content impact\n\nNo quickstarts or help topics change.\n", buf.String())
+}
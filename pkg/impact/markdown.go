@@ -0,0 +1,190 @@
+package impact
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes the report as Markdown for a pull request comment.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "## Content impact")
+	fmt.Fprintln(b)
+	if r.Base != "" && r.Head != "" {
+		fmt.Fprintf(b, "Comparing %s with %s.\n\n", code(r.Base), code(r.Head))
+	}
+	if r.Quickstarts.Empty() && r.HelpTopics.Empty() {
+		fmt.Fprintln(b, "No quickstarts or help topics change.")
+		if len(r.Skipped) > 0 {
+			fmt.Fprintln(b)
+		}
+		r.writeSkipped(b)
+		return b.Flush()
+	}
+
+	fmt.Fprintln(b, "| | Added | Removed | Renamed | Changed |")
+	fmt.Fprintln(b, "|---|---:|---:|---:|---:|")
+	for _, row := range []struct {
+		name    string
+		changes Changes
+	}{{"Quickstarts", r.Quickstarts}, {"Help topics", r.HelpTopics}} {
+		fmt.Fprintf(b, "| %s | %d | %d | %d | %d |\n", row.name,
+			len(row.changes.Added), len(row.changes.Removed), len(row.changes.Renamed), len(row.changes.Changed))
+	}
+	fmt.Fprintln(b)
+	r.writeUsersSummary(b)
+
+	if len(r.Quickstarts.Removed) > 0 {
+		fmt.Fprintln(b, "### Removed quickstarts")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Quickstart | Display name"+r.usersHeader())
+		fmt.Fprintln(b, "|---|---"+r.usersSeparator())
+		for _, item := range r.Quickstarts.Removed {
+			fmt.Fprintf(b, "| %s | %s%s\n", code(item.Name), cell(item.DisplayName), r.usersCells(item.Name))
+		}
+		fmt.Fprintln(b)
+	}
+
+	if len(r.Quickstarts.Renamed) > 0 {
+		fmt.Fprintln(b, "### Renamed quickstarts")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "Seeding moves favorites and progress to the new name when it lists the old one under `previousNames` in its metadata. "+
+			"Otherwise it treats a rename as a removal and an addition: favorites and progress stay with the old name.")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| From | To | Matched by | Users"+r.usersHeader())
+		fmt.Fprintln(b, "|---|---|---|---"+r.usersSeparator())
+		for _, rename := range r.Quickstarts.Renamed {
+			users := "orphaned"
+			if rename.Aliased {
+				users = "moved"
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %s%s\n", code(rename.From.Name), code(rename.To.Name), rename.Reason, users, r.usersCells(rename.From.Name))
+		}
+		fmt.Fprintln(b)
+	}
+
+	if len(r.Quickstarts.Added) > 0 {
+		fmt.Fprintln(b, "### Added quickstarts")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Quickstart | Display name | Tags |")
+		fmt.Fprintln(b, "|---|---|---|")
+		for _, item := range r.Quickstarts.Added {
+			fmt.Fprintf(b, "| %s | %s | %s |\n", code(item.Name), cell(item.DisplayName), codes(item.Tags))
+		}
+		fmt.Fprintln(b)
+	}
+
+	if len(r.Filters) > 0 {
+		fmt.Fprintln(b, "### Filters")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "Quickstarts whose tag changes move them in or out of a catalog filter.")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Filter | Tag | Entering | Leaving |")
+		fmt.Fprintln(b, "|---|---|---|---|")
+		for _, f := range r.Filters {
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", cell(f.Label), code(f.Tag), codes(f.Entering), codes(f.Leaving))
+		}
+		fmt.Fprintln(b)
+	}
+
+	if len(r.Quickstarts.Changed) > 0 {
+		fmt.Fprintln(b, "### Changed quickstarts")
+		fmt.Fprintln(b)
+		for _, item := range r.Quickstarts.Changed {
+			fmt.Fprintf(b, "- %s (%s)\n", code(item.Name), code(item.File))
+		}
+		fmt.Fprintln(b)
+	}
+
+	if !r.HelpTopics.Empty() {
+		fmt.Fprintln(b, "### Help topics")
+		fmt.Fprintln(b)
+		for _, row := range []struct {
+			label string
+			items []Item
+		}{{"Added", r.HelpTopics.Added}, {"Removed", r.HelpTopics.Removed}, {"Changed", r.HelpTopics.Changed}} {
+			if len(row.items) == 0 {
+				continue
+			}
+			names := make([]string, len(row.items))
+			for i, item := range row.items {
+				names[i] = item.Name
+			}
+			fmt.Fprintf(b, "- %s: %s\n", row.label, codes(names))
+		}
+		fmt.Fprintln(b)
+	}
+
+	r.writeSkipped(b)
+	return b.Flush()
+}
+
+func (r *Report) writeUsersSummary(w io.Writer) {
+	orphaned := r.Orphaned()
+	switch {
+	case len(orphaned) == 0:
+		fmt.Fprintln(w, "No quickstart is removed or renamed without a previous name, so no favorites or progress are orphaned.")
+	case r.Users == nil:
+		fmt.Fprintf(w, "%d removed or renamed quickstarts would orphan their favorites and progress; users were not counted.\n", len(orphaned))
+	default:
+		var favorites, progress int
+		for _, name := range orphaned {
+			favorites += r.Users[name].Favorites
+			progress += r.Users[name].InProgress
+		}
+		fmt.Fprintf(w, "**%d favorites and %d in-progress quickstarts would be orphaned.**\n", favorites, progress)
+	}
+	fmt.Fprintln(w)
+}
+
+func (r *Report) writeSkipped(w io.Writer) {
+	if len(r.Skipped) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "### Skipped by seeding")
+	fmt.Fprintln(w)
+	for _, skipped := range r.Skipped {
+		fmt.Fprintf(w, "- %s\n", skipped)
+	}
+}
+
+func (r *Report) usersHeader() string {
+	if r.Users == nil {
+		return " |"
+	}
+	return " | Favorites | In progress |"
+}
+
+func (r *Report) usersSeparator() string {
+	if r.Users == nil {
+		return "|"
+	}
+	return "|---:|---:|"
+}
+
+func (r *Report) usersCells(name string) string {
+	if r.Users == nil {
+		return " |"
+	}
+	usage := r.Users[name]
+	return fmt.Sprintf(" | %d | %d |", usage.Favorites, usage.InProgress)
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
+
+func code(text string) string {
+	return "`" + cell(text) + "`"
+}
+
+func codes(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = code(v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,51 @@
+package impact
+
+import (
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/gorm"
+)
+
+// CountUsers counts, in db, the favorites and progress records of the
+// quickstarts the change removes or renames. Favorites that were unset do not
+// count.
+func (r *Report) CountUsers(db *gorm.DB) error {
+	r.Users = map[string]Usage{}
+	names := r.gone(true)
+	if len(names) == 0 {
+		return nil
+	}
+
+	type count struct {
+		QuickstartName string
+		Count          int
+	}
+	var favorites, progress []count
+	err := db.Model(&models.FavoriteQuickstart{}).
+		Select("quickstart_name, count(*) AS count").
+		Where("quickstart_name IN ? AND favorite = ?", names, true).
+		Group("quickstart_name").
+		Scan(&favorites).Error
+	if err != nil {
+		return err
+	}
+	err = db.Model(&models.QuickstartProgress{}).
+		Select("quickstart_name, count(*) AS count").
+		Where("quickstart_name IN ?", names).
+		Group("quickstart_name").
+		Scan(&progress).Error
+	if err != nil {
+		return err
+	}
+
+	for _, c := range favorites {
+		usage := r.Users[c.QuickstartName]
+		usage.Favorites = c.Count
+		r.Users[c.QuickstartName] = usage
+	}
+	for _, c := range progress {
+		usage := r.Users[c.QuickstartName]
+		usage.InProgress = c.Count
+		r.Users[c.QuickstartName] = usage
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type StructuredLogger struct {
@@ -35,6 +36,9 @@ func (l *StructuredLogger) NewLogEntry(r *http.Request) middleware.LogEntry {
 	if reqID != "" {
 		fmt.Fprintf(entry.buf, "[%s] ", reqID)
 	}
+	if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+		fmt.Fprintf(entry.buf, "[trace_id=%s] ", sc.TraceID())
+	}
 
 	fmt.Fprintf(entry.buf, "\"")
 	fmt.Fprintf(entry.buf, "%s ", r.Method)
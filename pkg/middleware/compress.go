@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/andybalholm/brotli"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// compressionLevel balances CPU cost against payload size for JSON listings.
+// It is used for both gzip (1-9) and brotli (0-11).
+const compressionLevel = 5
+
+// Compress negotiates response compression from Accept-Encoding. Brotli is
+// preferred over gzip and deflate when the client accepts it. Only JSON
+// responses, including problem+json errors, are compressed.
+func Compress() func(http.Handler) http.Handler {
+	compressor := chimiddleware.NewCompressor(compressionLevel, "application/json", "application/problem+json")
+	compressor.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+		return brotli.NewWriterLevel(w, level)
+	})
+	return compressor.Handler
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamedItem struct {
+	Name string `json:"name"`
+}
+
+func streamingHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timing := utils.NewServerTiming()
+		timing.Start("db")()
+		names := []string{"first", "second", strings.Repeat("x", 2048)}
+		utils.StreamDataResponse(w, http.StatusOK, timing, names, func(n string) streamedItem {
+			return streamedItem{Name: n}
+		})
+	})
+}
+
+func fetch(t *testing.T, server *httptest.Server, acceptEncoding string) (*http.Response, []byte) {
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	// Setting Accept-Encoding disables the transport's transparent gzip handling.
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Trailers are only populated once the raw body has been read to EOF.
+	raw, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	var reader io.Reader = bytes.NewReader(raw)
+	switch resp.Header.Get("Content-Encoding") {
+	case "gzip":
+		gz, err := gzip.NewReader(reader)
+		assert.NoError(t, err)
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(reader)
+	}
+	body, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	return resp, body
+}
+
+func TestCompress(t *testing.T) {
+	server := httptest.NewServer(Compress()(streamingHandler()))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{acceptEncoding: "gzip, deflate, br", expected: "br"},
+		{acceptEncoding: "gzip", expected: "gzip"},
+		{acceptEncoding: "identity", expected: ""},
+	} {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			resp, body := fetch(t, server, tc.acceptEncoding)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.expected, resp.Header.Get("Content-Encoding"))
+
+			var payload struct {
+				Data []streamedItem `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			assert.Len(t, payload.Data, 3)
+			assert.Equal(t, "first", payload.Data[0].Name)
+
+			assert.Contains(t, resp.Header.Get("Server-Timing"), "db;dur=")
+			assert.Contains(t, resp.Trailer.Get("Server-Timing"), "serialize;dur=")
+		})
+	}
+}
+
+func TestStreamDataResponseEmpty(t *testing.T) {
+	w := httptest.NewRecorder()
+	utils.StreamDataResponse(w, http.StatusOK, nil, []string(nil), func(s string) string { return s })
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Server-Timing"))
+}
@@ -0,0 +1,301 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultMaxBodySize caps the request bodies the validator reads when
+// OpenAPIValidatorOptions.MaxBodySize is not set.
+const DefaultMaxBodySize = 5 * 1024 * 1024 // 5MB
+
+// streamedMediaTypes are content archives. They are opaque binary strings to
+// the spec, so their bodies are not validated and are left for the handler
+// to stream under its own size limit.
+var streamedMediaTypes = map[string]bool{
+	"application/x-ndjson": true,
+	"application/gzip":     true,
+}
+
+func init() {
+	// Error responses are validated like any other JSON body.
+	openapi3filter.RegisterBodyDecoder(utils.ProblemContentType, openapi3filter.JSONBodyDecoder)
+	for mediaType := range streamedMediaTypes {
+		openapi3filter.RegisterBodyDecoder(mediaType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+// OpenAPIValidatorOptions configures OpenAPIValidator.
+type OpenAPIValidatorOptions struct {
+	// BasePath is the prefix the API is mounted under. Spec paths are matched
+	// relative to it.
+	BasePath string
+	// ValidateResponses buffers every response and checks it against the spec.
+	// Mismatches are logged. It is meant for tests and non-production
+	// environments because responses are no longer streamed.
+	ValidateResponses bool
+	// Strict replaces responses that do not match the spec with a 500 so
+	// contract tests in CI fail on response schema drift. It implies
+	// ValidateResponses.
+	Strict bool
+	// MaxBodySize caps the request bodies read for validation; larger ones
+	// are rejected with a 413 before the handler runs. Defaults to
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+}
+
+// OpenAPIValidator loads the OpenAPI document at specPath and returns
+// middleware that rejects requests that do not match it with a 400 problem
+// listing every invalid parameter and body field. Query parameters the
+// operation does not declare are rejected too; the legacy "param[]" form is
+// accepted for declared parameters. Requests for paths the spec does not
+// describe are passed through unchanged. Validation reads the whole body,
+// so bodies are capped at MaxBodySize, except content archives, which are
+// not validated.
+func OpenAPIValidator(specPath string, opts OpenAPIValidatorOptions) (func(http.Handler) http.Handler, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec %s: %w", specPath, err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec %s: %w", specPath, err)
+	}
+	// Match spec paths directly; the base path is stripped per request.
+	doc.Servers = nil
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	v := &openAPIValidator{
+		router:            router,
+		basePath:          strings.TrimSuffix(opts.BasePath, "/"),
+		validateResponses: opts.ValidateResponses || opts.Strict,
+		strict:            opts.Strict,
+		maxBodySize:       opts.MaxBodySize,
+	}
+	if v.maxBodySize <= 0 {
+		v.maxBodySize = DefaultMaxBodySize
+	}
+	return v.handler, nil
+}
+
+type openAPIValidator struct {
+	router            routers.Router
+	basePath          string
+	validateResponses bool
+	strict            bool
+	maxBodySize       int64
+}
+
+func (v *openAPIValidator) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, v.basePath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		streamed := streamedMediaTypes[mediaType]
+		if !streamed && r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, v.maxBodySize)
+		}
+
+		// Route and validate a copy whose path is relative to the base path.
+		specReq := r.Clone(r.Context())
+		specReq.URL.Path = strings.TrimPrefix(r.URL.Path, v.basePath)
+		specReq.URL.RawPath = ""
+		route, pathParams, err := v.router.FindRoute(specReq)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    specReq,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				ExcludeRequestBody: streamed,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		fields := unknownQueryParams(route, specReq)
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				utils.ErrorResponse(w, r, maxBytesErr)
+				return
+			}
+			fields = append(fields, requestFieldErrors(err)...)
+		}
+		if len(fields) > 0 {
+			utils.ErrorResponse(w, r, utils.RequestValidationError("request does not match the API specification", fields))
+			return
+		}
+		// Body validation consumed the original body and left a replay on the copy.
+		r.Body = specReq.Body
+
+		if !v.validateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		v.writeValidated(w, r, input, rec)
+	})
+}
+
+// writeValidated checks a buffered response against the spec and replays it.
+func (v *openAPIValidator) writeValidated(w http.ResponseWriter, r *http.Request, input *openapi3filter.RequestValidationInput, rec *bufferedResponse) {
+	err := openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.status,
+		Header:                 rec.header,
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"status": rec.status,
+		}).Warn("Response does not match the OpenAPI spec")
+		if v.strict {
+			utils.ErrorResponse(w, r, &utils.APIError{
+				Kind:   utils.KindInternal,
+				Detail: "response does not match the API specification",
+				Err:    err,
+			})
+			return
+		}
+	}
+
+	for k, vv := range rec.header {
+		w.Header()[k] = vv
+	}
+	w.WriteHeader(rec.status)
+	w.Write(rec.body.Bytes())
+}
+
+// unknownQueryParams reports query parameters the operation does not declare.
+func unknownQueryParams(route *routers.Route, r *http.Request) []generated.ProblemFieldError {
+	declared := make(map[string]bool)
+	for _, params := range []openapi3.Parameters{route.PathItem.Parameters, route.Operation.Parameters} {
+		for _, p := range params {
+			if p.Value != nil && p.Value.In == openapi3.ParameterInQuery {
+				declared[p.Value.Name] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(r.URL.Query()))
+	for key := range r.URL.Query() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fields []generated.ProblemFieldError
+	for _, key := range keys {
+		if declared[strings.TrimSuffix(key, "[]")] {
+			continue
+		}
+		fields = append(fields, generated.ProblemFieldError{
+			In:     openapi3.ParameterInQuery,
+			Name:   key,
+			Reason: "unknown query parameter",
+		})
+	}
+	return fields
+}
+
+// requestFieldErrors flattens kin-openapi validation errors into problem
+// fields. With MultiError enabled, body schema failures may be reported
+// either wrapped in a RequestError or as bare SchemaErrors.
+func requestFieldErrors(err error) []generated.ProblemFieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []generated.ProblemFieldError
+		for _, inner := range e {
+			fields = append(fields, requestFieldErrors(inner)...)
+		}
+		return fields
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			return []generated.ProblemFieldError{{
+				In:     e.Parameter.In,
+				Name:   e.Parameter.Name,
+				Reason: validationReason(e),
+			}}
+		}
+		if e.Err != nil {
+			if fields := requestFieldErrors(e.Err); len(fields) > 0 && fields[0].In == "body" {
+				return fields
+			}
+		}
+		return []generated.ProblemFieldError{{In: "body", Name: "/", Reason: validationReason(e)}}
+	case *openapi3.SchemaError:
+		return []generated.ProblemFieldError{{
+			In:     "body",
+			Name:   "/" + strings.Join(e.JSONPointer(), "/"),
+			Reason: e.Reason,
+		}}
+	}
+	return []generated.ProblemFieldError{{In: "request", Name: "", Reason: err.Error()}}
+}
+
+func validationReason(reqErr *openapi3filter.RequestError) string {
+	if se, ok := reqErr.Err.(*openapi3.SchemaError); ok {
+		return se.Reason
+	}
+	if reqErr.Err != nil {
+		return reqErr.Err.Error()
+	}
+	return reqErr.Reason
+}
+
+// bufferedResponse captures a response so it can be validated before it is
+// sent.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.wrote {
+		return
+	}
+	b.status = status
+	b.wrote = true
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.wrote = true
+	return b.body.Write(p)
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpecPath = "../../spec/openapi.json"
+
+type problemPayload struct {
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Errors []struct {
+		In     string `json:"in"`
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	} `json:"errors"`
+}
+
+func newValidatedHandler(t *testing.T, opts OpenAPIValidatorOptions, next http.HandlerFunc) http.Handler {
+	t.Helper()
+	validator, err := OpenAPIValidator(testSpecPath, opts)
+	require.NoError(t, err)
+	return validator(next)
+}
+
+func TestOpenAPIValidatorRequests(t *testing.T) {
+	var reachedBody string
+	handler := newValidatedHandler(t, OpenAPIValidatorOptions{BasePath: "/api/quickstarts/v1"}, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		reachedBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantField  string
+	}{
+		{"valid listing", http.MethodGet, "/api/quickstarts/v1/quickstarts?bundle=rhel&view=summary", "", http.StatusOK, ""},
+		{"legacy array form", http.MethodGet, "/api/quickstarts/v1/quickstarts?bundle[]=rhel", "", http.StatusOK, ""},
+		{"invalid enum", http.MethodGet, "/api/quickstarts/v1/quickstarts?view=compact", "", http.StatusBadRequest, "view"},
+		{"wrong query type", http.MethodGet, "/api/quickstarts/v1/quickstarts?limit=ten", "", http.StatusBadRequest, "limit"},
+		{"unknown query param", http.MethodGet, "/api/quickstarts/v1/helptopics?colour=blue", "", http.StatusBadRequest, "colour"},
+		{"missing required param", http.MethodGet, "/api/quickstarts/v1/favorites", "", http.StatusBadRequest, "account"},
+		{"wrong body type", http.MethodPost, "/api/quickstarts/v1/progress", `{"accountId":"abc","quickstartName":"qs"}`, http.StatusBadRequest, "/accountId"},
+		{"path outside the spec", http.MethodGet, "/api/quickstarts/v1/spec/openapi.json?anything=1", "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantField == "" {
+				return
+			}
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			var payload problemPayload
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+			require.NotEmpty(t, payload.Errors)
+			assert.Equal(t, tt.wantField, payload.Errors[0].Name)
+		})
+	}
+
+	t.Run("valid body reaches the handler intact", func(t *testing.T) {
+		body := `{"accountId":1,"quickstartName":"qs","progress":{"step":1}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/quickstarts/v1/progress", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, body, reachedBody)
+	})
+}
+
+func TestOpenAPIValidatorResponses(t *testing.T) {
+	drifted := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":"not a list"}`))
+	}
+
+	t.Run("warn mode passes drifted responses through", func(t *testing.T) {
+		handler := newValidatedHandler(t, OpenAPIValidatorOptions{ValidateResponses: true}, drifted)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/quickstarts", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"data":"not a list"}`, w.Body.String())
+	})
+
+	t.Run("strict mode fails drifted responses", func(t *testing.T) {
+		handler := newValidatedHandler(t, OpenAPIValidatorOptions{Strict: true}, drifted)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/quickstarts", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "response does not match the API specification")
+	})
+
+	t.Run("strict mode passes conforming responses", func(t *testing.T) {
+		handler := newValidatedHandler(t, OpenAPIValidatorOptions{Strict: true}, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data":[{"id":1,"name":"qs","content":{}}]}`))
+		})
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/quickstarts", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// countingReader serves size bytes of filler and counts how many were read.
+type countingReader struct {
+	size, read int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	if c.read >= c.size {
+		return 0, io.EOF
+	}
+	n := int64(len(p))
+	if remaining := c.size - c.read; n > remaining {
+		n = remaining
+	}
+	for i := range p[:n] {
+		p[i] = ' '
+	}
+	c.read += n
+	return int(n), nil
+}
+
+func TestOpenAPIValidatorBodyLimit(t *testing.T) {
+	const limit = 1024
+	reached := false
+	handler := newValidatedHandler(t, OpenAPIValidatorOptions{BasePath: "/api/quickstarts/v1", MaxBodySize: limit}, func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		n, _ := io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fmt.Sprintf(`{"read":%d}`, n)))
+	})
+
+	t.Run("oversized bodies are rejected before they are read", func(t *testing.T) {
+		body := &countingReader{size: 64 * 1024 * 1024}
+		req := httptest.NewRequest(http.MethodPost, "/api/quickstarts/v1/progress", body)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.False(t, reached)
+		assert.Less(t, body.read, int64(64*1024), "the body is not read past the limit")
+	})
+
+	t.Run("archives are left for the handler to stream", func(t *testing.T) {
+		reached = false
+		body := &countingReader{size: 4 * limit}
+		req := httptest.NewRequest(http.MethodPost, "/api/quickstarts/v1/admin/import", body)
+		req.Header.Set("Content-Type", "application/gzip")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.True(t, reached)
+		assert.Equal(t, `{"read":4096}`, w.Body.String())
+	})
+}
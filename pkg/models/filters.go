@@ -90,3 +90,21 @@ var (
 		},
 	}
 )
+
+// Bundles lists the console bundles content can be tagged with. Bundle tags
+// with any other value match no bundle in the console.
+var Bundles = []string{
+	"allservices",
+	"ansible",
+	"application-services",
+	"application-studio",
+	"edge",
+	"hac",
+	"iam",
+	"insights",
+	"internal",
+	"landing",
+	"openshift",
+	"settings",
+	"subscriptions",
+}
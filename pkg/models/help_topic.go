@@ -14,6 +14,7 @@ type HelpTopic struct {
 	Name      string         `gorm:"unique;not null;default:null" json:"name"`
 	Content   datatypes.JSON `gorm:"type: JSONB" json:"content,omitempty"`
 	Tags      []Tag          `gorm:"many2many:help_topic_tags;" json:"tags,omitempty"`
+	Lifecycle
 }
 
 type Link struct {
@@ -54,5 +55,11 @@ func (ht HelpTopic) ToAPI() generated.HelpTopic {
 		gen.Tags = &tags
 	}
 
+	gen.Lifecycle = ht.Lifecycle.toAPI()
+	if ht.Deprecated() {
+		deprecated := true
+		gen.Deprecated = &deprecated
+	}
+
 	return gen
 }
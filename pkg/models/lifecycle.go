@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+)
+
+// Lifecycle statuses, set by the status field of the metadata.
+const (
+	StatusDraft      = "draft"
+	StatusPublished  = "published"
+	StatusDeprecated = "deprecated"
+)
+
+// Lifecycle is the publishing state of a quickstart or help topic. Only
+// published and deprecated items inside their publishing window are served,
+// unless drafts are requested.
+type Lifecycle struct {
+	Status      string     `gorm:"not null;default:published" json:"status"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`   // Hidden until then
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"` // Hidden from then on
+	Replacement string     `json:"replacement,omitempty"` // Name of the item replacing a deprecated one
+}
+
+// Visible reports whether the item is served at now without drafts.
+func (l Lifecycle) Visible(now time.Time) bool {
+	if l.Status == StatusDraft {
+		return false
+	}
+	if l.PublishAt != nil && now.Before(*l.PublishAt) {
+		return false
+	}
+	return l.UnpublishAt == nil || now.Before(*l.UnpublishAt)
+}
+
+// Deprecated reports whether the item is deprecated.
+func (l Lifecycle) Deprecated() bool {
+	return l.Status == StatusDeprecated
+}
+
+// toAPI returns the lifecycle for API responses, or nil for items that are
+// simply published.
+func (l Lifecycle) toAPI() *generated.Lifecycle {
+	if (l.Status == "" || l.Status == StatusPublished) && l.PublishAt == nil && l.UnpublishAt == nil {
+		return nil
+	}
+	gen := &generated.Lifecycle{
+		Status:      generated.LifecycleStatus(l.Status),
+		PublishAt:   l.PublishAt,
+		UnpublishAt: l.UnpublishAt,
+	}
+	if gen.Status == "" {
+		gen.Status = generated.Published
+	}
+	if l.Replacement != "" {
+		gen.Replacement = &l.Replacement
+	}
+	return gen
+}
@@ -14,6 +14,11 @@ type Quickstart struct {
 	Content            datatypes.JSON       `gorm:"type: JSONB" json:"content,omitempty"`
 	Tags               []Tag                `gorm:"many2many:quickstart_tags;" json:"tags,omitempty"`
 	FavoriteQuickstart []FavoriteQuickstart `gorm:"foreignKey:QuickstartName;references:Name" json:"favoriteQuickstart"`
+	SourceFile         string               `json:"sourceFile,omitempty"`     // Content file the quickstart was seeded from
+	SourceRevision     string               `json:"sourceRevision,omitempty"` // Revision of the content source at seeding time
+	PreviousNames      []string             `gorm:"-" json:"-"`               // From the metadata; stored as QuickstartAlias rows, not loaded with the quickstart
+	RedirectedFrom     string               `gorm:"-" json:"-"`               // Previous name the quickstart was looked up by
+	Lifecycle
 }
 
 // ToAPI converts Quickstart to generated.Quickstart for API responses
@@ -48,6 +53,23 @@ func (q Quickstart) ToAPI() generated.Quickstart {
 		gen.Tags = &tags
 	}
 
+	if q.SourceFile != "" {
+		gen.Source = &generated.QuickstartSource{File: q.SourceFile}
+		if q.SourceRevision != "" {
+			gen.Source.Revision = &q.SourceRevision
+		}
+	}
+
+	gen.Lifecycle = q.Lifecycle.toAPI()
+	if q.Deprecated() {
+		deprecated := true
+		gen.Deprecated = &deprecated
+	}
+
+	if q.RedirectedFrom != "" {
+		gen.RedirectedFrom = &q.RedirectedFrom
+	}
+
 	// Convert favorite quickstarts
 	if len(q.FavoriteQuickstart) > 0 {
 		favs := make([]generated.FavoriteQuickstart, len(q.FavoriteQuickstart))
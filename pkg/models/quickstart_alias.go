@@ -0,0 +1,9 @@
+package models
+
+// QuickstartAlias maps a previous name of a renamed quickstart, listed under
+// previousNames in its metadata, to its current name. Seeding replaces every
+// alias on each run.
+type QuickstartAlias struct {
+	Name           string `gorm:"primaryKey" json:"name"`
+	QuickstartName string `gorm:"not null;index" json:"quickstartName"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// SeedGenerationID is the primary key of the single seed_generations row.
+const SeedGenerationID = 1
+
+// SeedGeneration is a single-row marker bumped by every successful content
+// seed. Replicas compare it against the generation they last loaded to decide
+// whether in-memory catalog data is stale. Source and Revision record where
+// the content of the latest generation came from.
+type SeedGeneration struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	Generation int64     `gorm:"not null;default:0" json:"generation"`
+	Source     string    `json:"source"`   // Content directory, repository URL or archive path
+	Revision   string    `json:"revision"` // Commit SHA or archive digest; empty when unknown
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"gorm.io/datatypes"
+)
+
+// Seed run statuses.
+const (
+	SeedRunSucceeded = "succeeded"
+	SeedRunFailed    = "failed"
+)
+
+// SeedItemError is a content file that could not be seeded.
+type SeedItemError struct {
+	Kind  string `json:"kind"` // "quickstart" or "helptopic"
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// SeedRun records the outcome of one content seeding run. Created, Updated
+// and Deleted count quickstarts and help topics compared with the catalog
+// before the run.
+type SeedRun struct {
+	ID               uint                               `gorm:"primarykey" json:"id"`
+	StartedAt        time.Time                          `gorm:"not null" json:"startedAt"`
+	FinishedAt       time.Time                          `gorm:"not null" json:"finishedAt"`
+	Status           string                             `gorm:"not null" json:"status"`
+	Source           string                             `json:"source"`
+	Revision         string                             `json:"revision"`
+	Created          int                                `gorm:"not null;default:0" json:"created"`
+	Updated          int                                `gorm:"not null;default:0" json:"updated"`
+	Deleted          int                                `gorm:"not null;default:0" json:"deleted"`
+	QuickstartErrors int                                `gorm:"not null;default:0" json:"quickstartErrors"`
+	HelpTopicErrors  int                                `gorm:"not null;default:0" json:"helpTopicErrors"`
+	Errors           datatypes.JSONSlice[SeedItemError] `json:"errors"`
+	Error            string                             `json:"error,omitempty"` // Why a failed run was rolled back
+}
+
+// ToAPI converts SeedRun to generated.SeedRun for API responses
+func (r SeedRun) ToAPI() generated.SeedRun {
+	gen := generated.SeedRun{
+		Id:               int(r.ID),
+		StartedAt:        r.StartedAt,
+		FinishedAt:       r.FinishedAt,
+		Status:           generated.SeedRunStatus(r.Status),
+		Source:           r.Source,
+		Revision:         r.Revision,
+		Created:          r.Created,
+		Updated:          r.Updated,
+		Deleted:          r.Deleted,
+		QuickstartErrors: r.QuickstartErrors,
+		HelpTopicErrors:  r.HelpTopicErrors,
+		Errors:           make([]generated.SeedItemError, len(r.Errors)),
+	}
+	for i, e := range r.Errors {
+		gen.Errors[i] = generated.SeedItemError{Kind: e.Kind, File: e.File, Error: e.Error}
+	}
+	if r.Error != "" {
+		gen.Error = &r.Error
+	}
+	return gen
+}
@@ -0,0 +1,281 @@
+// Package preview serves the quickstarts API straight from a content
+// directory, without a database, and reloads it when the content changes.
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	qsmiddleware "github.com/RedHatInsights/quickstarts/pkg/middleware"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+	"github.com/RedHatInsights/quickstarts/pkg/routes"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+	"github.com/go-chi/chi/v5"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/sirupsen/logrus"
+)
+
+const apiBasePath = "/api/quickstarts/v1"
+
+// Status describes the content currently served and how it validated.
+type Status struct {
+	Generation  int                   `json:"generation"` // Incremented on every reload
+	Quickstarts int                   `json:"quickstarts"`
+	HelpTopics  int                   `json:"helpTopics"`
+	Errors      int                   `json:"errors"`
+	Warnings    int                   `json:"warnings"`
+	Diagnostics []validate.Diagnostic `json:"diagnostics"`
+}
+
+// Server serves the content under a directory through the API handlers,
+// backed by in-memory repositories that are rebuilt on every reload.
+type Server struct {
+	base   string
+	schema *jsonschema.Schema
+	opts   validate.Options
+
+	mu          sync.RWMutex
+	api         http.Handler
+	status      Status
+	fingerprint string // Of the content the current status was built from
+	subscribers map[chan Status]struct{}
+}
+
+// New returns a server for the content under base, validated with opts;
+// call Reload before serving.
+func New(base string, schema *jsonschema.Schema, opts validate.Options) *Server {
+	return &Server{
+		base:        base,
+		schema:      schema,
+		opts:        opts,
+		api:         http.NotFoundHandler(),
+		subscribers: map[chan Status]struct{}{},
+	}
+}
+
+// Reload rebuilds the served content and its validation report, and sends
+// the new status to every event stream. Items that cannot be loaded are left
+// out, as seeding leaves them out; the report says why.
+func (s *Server) Reload() Status {
+	// Taken first, so a change made while loading triggers another reload.
+	fp := fingerprint(s.base)
+	m := repository.NewMemory()
+	quickstarts, helpTopics := load(m, s.base)
+	report := validate.Content(s.base, s.schema, s.opts)
+
+	r := chi.NewRouter()
+	r.Use(qsmiddleware.ExtractIdentity)
+	r.Use(authorIdentity)
+	generated.HandlerWithOptions(routes.NewServerAdapter(m.Repositories()), generated.ChiServerOptions{
+		BaseURL:          apiBasePath,
+		BaseRouter:       r,
+		ErrorHandlerFunc: routes.ParamErrorHandler,
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	diagnostics := report.Diagnostics
+	if diagnostics == nil {
+		diagnostics = []validate.Diagnostic{}
+	}
+	s.api = r
+	s.fingerprint = fp
+	s.status = Status{
+		Generation:  s.status.Generation + 1,
+		Quickstarts: quickstarts,
+		HelpTopics:  helpTopics,
+		Errors:      report.Count(validate.SeverityError),
+		Warnings:    report.Count(validate.SeverityWarning),
+		Diagnostics: diagnostics,
+	}
+	for ch := range s.subscribers {
+		// A slow client only needs the latest status.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- s.status
+	}
+	return s.status
+}
+
+// authorIdentity treats requests without an identity as coming from an
+// internal user, so authors can see their drafts with includeDrafts=true.
+func authorIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(identity.Key).(identity.XRHID); !ok {
+			author := identity.XRHID{Identity: identity.Identity{Type: "User", User: identity.User{Internal: true}}}
+			r = r.WithContext(context.WithValue(r.Context(), identity.Key, author))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// load adds the quickstarts and help topics under base to m, built by the
+// same code that seeds the database, and returns how many it added.
+func load(m *repository.Memory, base string) (quickstarts, helpTopics int) {
+	metadataFiles, _ := filepath.Glob(filepath.Join(base, "quickstarts", "*", "metadata.y*"))
+	topicFiles, _ := filepath.Glob(filepath.Join(base, "help-topics", "*", "metadata.y*"))
+	for _, file := range append(metadataFiles, topicFiles...) {
+		item, err := database.Preview(file)
+		if err != nil {
+			logrus.WithError(err).WithField("file", file).Debug("Skipping content item")
+			continue
+		}
+		switch item := item.(type) {
+		case models.Quickstart:
+			m.AddQuickstart(item)
+			quickstarts++
+		case []models.HelpTopic:
+			for _, topic := range item {
+				m.AddHelpTopic(topic)
+				helpTopics++
+			}
+		}
+	}
+	return quickstarts, helpTopics
+}
+
+// Watch polls the content directory every interval and reloads when a file
+// is added, removed or modified, until ctx is done.
+func (s *Server) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.RLock()
+			last := s.fingerprint
+			s.mu.RUnlock()
+			if fingerprint(s.base) != last {
+				status := s.Reload()
+				logrus.Infof("Reloaded content: %d quickstarts, %d help topics, %d errors, %d warnings",
+					status.Quickstarts, status.HelpTopics, status.Errors, status.Warnings)
+			}
+		}
+	}
+}
+
+// fingerprint summarizes the names, sizes and modification times of the
+// files under base.
+func fingerprint(base string) string {
+	var b strings.Builder
+	filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			fmt.Fprintf(&b, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return b.String()
+}
+
+// Handler serves the API under /api/quickstarts/v1, the current status at
+// /preview/status and a stream of status updates, one per reload, as
+// server-sent events at /preview/events. Any origin may call it, so a
+// frontend dev server can use it directly.
+func (s *Server) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(allowAnyOrigin)
+	r.Get("/preview/status", s.serveStatus)
+	r.Get("/preview/events", s.serveEvents)
+	r.Handle(apiBasePath+"/*", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		api := s.api
+		s.mu.RUnlock()
+		api.ServeHTTP(w, r)
+	}))
+	return r
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	status := s.status
+	s.mu.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// serveEvents streams a "status" event with the current status, then one
+// per reload until the client disconnects.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan Status, 1)
+	s.mu.Lock()
+	ch <- s.status
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case status := <-ch:
+			data, err := json.Marshal(status)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", status.Generation, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func allowAnyOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Headers", "*")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListenAndServe serves s on addr until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package preview
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, base string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(base, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+const quickstartYAML = `metadata:
+  name: first
+spec:
+  displayName: First
+  description: The first quick start.
+  durationMinutes: 5
+  type:
+    text: Quick start
+    color: green
+`
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	config.Init()
+	base := t.TempDir()
+	writeFiles(t, base, map[string]string{
+		"quickstarts/first/metadata.yml": "kind: QuickStarts\nname: first\ntags:\n  - kind: bundle\n    value: insights\n",
+		"quickstarts/first/first.yml":    quickstartYAML,
+		"help-topics/group/metadata.yml": "kind: HelpTopic\nname: group\nstatus: draft\n",
+		"help-topics/group/group.yml":    "- name: topic\n  title: Topic\n  content: Content\n",
+	})
+	schema, err := validate.LoadSchema("../../spec/quickstart.schema.json")
+	require.NoError(t, err)
+	return New(base, schema, validate.Options{}), base
+}
+
+func TestServeContent(t *testing.T) {
+	s, _ := newTestServer(t)
+	status := s.Reload()
+	assert.Equal(t, Status{Generation: 1, Quickstarts: 1, HelpTopics: 1, Diagnostics: []validate.Diagnostic{}}, status)
+
+	handler := s.Handler()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/quickstarts/v1/quickstarts?bundle=insights", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var quickstarts struct {
+		Data []struct {
+			Name    string                 `json:"name"`
+			Content map[string]interface{} `json:"content"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&quickstarts))
+	require.Len(t, quickstarts.Data, 1)
+	assert.Equal(t, "first", quickstarts.Data[0].Name)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// Drafts are hidden unless asked for; authors need no identity to ask.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/quickstarts/v1/helptopics?name=topic", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"groupName":"group"`)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/quickstarts/v1/helptopics?name=topic&includeDrafts=true", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"groupName":"group"`)
+}
+
+func TestEventsReportReloads(t *testing.T) {
+	s, base := newTestServer(t)
+	s.Reload()
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/preview/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(resp.Body)
+	next := func() Status {
+		t.Helper()
+		var status Status
+		for {
+			line, err := events.ReadString('\n')
+			require.NoError(t, err)
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				require.NoError(t, json.Unmarshal([]byte(data), &status))
+				return status
+			}
+		}
+	}
+	assert.Equal(t, 1, next().Generation)
+
+	// Breaking the quickstart takes it out of the API and reports why.
+	writeFiles(t, base, map[string]string{"quickstarts/first/first.yml": strings.Replace(quickstartYAML, "metadata:", "metadata: [", 1)})
+	s.Reload()
+	status := next()
+	assert.Equal(t, 2, status.Generation)
+	assert.Equal(t, 0, status.Quickstarts)
+	assert.Equal(t, 1, status.Errors)
+	require.Len(t, status.Diagnostics, 1)
+	assert.Equal(t, filepath.Join(base, "quickstarts/first/first.yml"), status.Diagnostics[0].File)
+}
+
+func TestWatchReloadsOnChange(t *testing.T) {
+	s, base := newTestServer(t)
+	s.Reload()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Watch(ctx, 10*time.Millisecond)
+
+	writeFiles(t, base, map[string]string{"quickstarts/first/first.yml": strings.Replace(quickstartYAML, "First", "Renamed", 1)})
+	assert.Eventually(t, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.status.Generation == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
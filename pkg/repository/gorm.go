@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// NewGorm returns repositories backed by db. fuzzySearch reports whether db
+// has the fuzzystrmatch extension; without it FindFuzzy returns
+// ErrFuzzySearchUnsupported.
+//
+// When db has read replicas, catalog queries are served by a replica while
+// favorites and progress always use the primary, so users read their own
+// writes.
+func NewGorm(db *gorm.DB, fuzzySearch bool) Repositories {
+	primary := db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+	return Repositories{
+		Quickstarts: &gormQuickstarts{db: db, fuzzySearch: fuzzySearch},
+		HelpTopics:  &gormHelpTopics{db: db},
+		Tags:        &gormTags{db: db},
+		Favorites:   &gormFavorites{db: primary},
+		Progress:    &gormProgress{db: primary},
+		Archive:     &gormArchive{db: db},
+		SeedRuns:    &gormSeedRuns{db: db},
+	}
+}
+
+// withContext binds db to ctx, so queries are cancelled with the request and
+// traced as its children.
+func withContext(db *gorm.DB, ctx context.Context) *gorm.DB {
+	if ctx == nil {
+		return db
+	}
+	return db.WithContext(ctx)
+}
+
+type gormHelpTopics struct {
+	db *gorm.DB
+}
+
+func (r *gormHelpTopics) FindByName(ctx context.Context, name string) (models.HelpTopic, error) {
+	var helpTopic models.HelpTopic
+	err := withContext(r.db, ctx).Where("name = ?", name).First(&helpTopic).Error
+	return helpTopic, err
+}
+
+// Find runs one query, joining in exactly as many tag‐filters as you need.
+func (r *gormHelpTopics) Find(ctx context.Context, f HelpTopicFilter, projection Projection) ([]models.HelpTopic, error) {
+	db := onlyVisible(withContext(r.db, ctx).Model(&models.HelpTopic{}), "help_topics", f.IncludeDrafts)
+	if projection != nil {
+		db = db.Select(
+			"help_topics.id, help_topics.created_at, help_topics.updated_at, help_topics.deleted_at, help_topics.group_name, help_topics.name, " +
+				"help_topics.status, help_topics.publish_at, help_topics.unpublish_at, help_topics.replacement, " +
+				projection.SQL(db.Dialector.Name(), "help_topics.content") + " AS content",
+		)
+	}
+
+	// name filter
+	if len(f.Names) > 0 {
+		db = db.Where("help_topics.name IN ?", f.Names)
+	}
+
+	// dynamic joins for each tag type through help_topic_tags junction table
+	for tagType, values := range f.Tags {
+		if len(values) == 0 {
+			continue
+		}
+		alias := fmt.Sprintf("t_%s", strings.ToLower(string(tagType)))
+		junctionAlias := fmt.Sprintf("htt_%s", strings.ToLower(string(tagType)))
+		db = db.
+			Joins(
+				fmt.Sprintf(
+					"JOIN help_topic_tags %s ON %s.help_topic_id = help_topics.id",
+					junctionAlias, junctionAlias,
+				),
+			).
+			Joins(
+				fmt.Sprintf(
+					"JOIN tags %s ON %s.tag_id = %s.id AND %s.type = ? AND %s.value IN ?",
+					alias, junctionAlias, alias, alias, alias,
+				),
+				tagType, values,
+			)
+	}
+
+	var result []models.HelpTopic
+	return result, db.Find(&result).Error
+}
+
+type gormTags struct {
+	db *gorm.DB
+}
+
+func (r *gormTags) Find(ctx context.Context, kind models.TagType) ([]models.Tag, error) {
+	db := withContext(r.db, ctx).Order("type, value")
+	if kind != "" {
+		db = db.Where("type = ?", kind)
+	}
+	var tags []models.Tag
+	return tags, db.Find(&tags).Error
+}
+
+type gormFavorites struct {
+	db *gorm.DB
+}
+
+func (r *gormFavorites) FindFavorites(ctx context.Context, accountId string) ([]models.FavoriteQuickstart, error) {
+	var favorites []models.FavoriteQuickstart
+	err := withContext(r.db, ctx).Where(&models.FavoriteQuickstart{AccountId: accountId, Favorite: true}).Find(&favorites).Error
+	return favorites, err
+}
+
+func (r *gormFavorites) Find(ctx context.Context, accountId, quickstartName string) (models.FavoriteQuickstart, error) {
+	var favorite models.FavoriteQuickstart
+	err := withContext(r.db, ctx).Where("account_id = ? AND quickstart_name = ?", accountId, quickstartName).First(&favorite).Error
+	return favorite, err
+}
+
+func (r *gormFavorites) Create(ctx context.Context, favorite *models.FavoriteQuickstart) error {
+	db := withContext(r.db, ctx)
+	var count int64
+	if err := db.Model(&models.Quickstart{}).Where("name = ?", favorite.QuickstartName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("quickstart %q: %w", favorite.QuickstartName, ErrNotFound)
+	}
+	return db.Create(favorite).Error
+}
+
+func (r *gormFavorites) SetFavorite(ctx context.Context, favorite *models.FavoriteQuickstart, value bool) error {
+	return withContext(r.db, ctx).Model(favorite).Update("favorite", value).Error
+}
+
+type gormProgress struct {
+	db *gorm.DB
+}
+
+func (r *gormProgress) Find(ctx context.Context, f ProgressFilter) ([]models.QuickstartProgress, error) {
+	var where models.QuickstartProgress
+	if f.AccountId != nil {
+		where.AccountId = *f.AccountId
+	}
+	if f.QuickstartName != nil {
+		where.QuickstartName = *f.QuickstartName
+	}
+
+	var progresses []models.QuickstartProgress
+	err := withContext(r.db, ctx).Where(where).Find(&progresses).Error
+	return progresses, err
+}
+
+func (r *gormProgress) FindOne(ctx context.Context, accountId int, quickstartName string) (models.QuickstartProgress, error) {
+	var progress models.QuickstartProgress
+	where := models.QuickstartProgress{QuickstartName: quickstartName, AccountId: accountId}
+	err := withContext(r.db, ctx).Where(where).First(&progress).Error
+	return progress, err
+}
+
+func (r *gormProgress) Save(ctx context.Context, progress *models.QuickstartProgress) error {
+	db := withContext(r.db, ctx)
+	if progress.ID == 0 {
+		return db.Create(progress).Error
+	}
+	return db.Save(progress).Error
+}
+
+func (r *gormProgress) Delete(ctx context.Context, id int) error {
+	db := withContext(r.db, ctx)
+	var progress models.QuickstartProgress
+	if err := db.First(&progress, id).Error; err != nil {
+		return err
+	}
+	return db.Delete(&progress).Error
+}
+
+// gormArchive runs exports and imports in their own transactions, which
+// always use the primary.
+type gormArchive struct {
+	db *gorm.DB
+}
+
+func (r *gormArchive) Export(ctx context.Context, w io.Writer, opts archive.Options) (archive.Counts, error) {
+	return database.ExportArchive(withContext(r.db, ctx), w, opts)
+}
+
+func (r *gormArchive) Import(ctx context.Context, rd io.Reader, opts archive.Options) (archive.Counts, error) {
+	return database.ImportArchive(withContext(r.db, ctx), rd, opts)
+}
+
+type gormSeedRuns struct {
+	db *gorm.DB
+}
+
+func (r *gormSeedRuns) Find(ctx context.Context, limit, offset int) ([]models.SeedRun, error) {
+	var runs []models.SeedRun
+	query := withContext(r.db, ctx).Order("started_at DESC, id DESC").Offset(offset)
+	if limit != -1 {
+		query = query.Limit(limit)
+	}
+	return runs, query.Find(&runs).Error
+}
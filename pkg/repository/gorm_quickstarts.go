@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type gormQuickstarts struct {
+	db          *gorm.DB
+	fuzzySearch bool
+}
+
+// selectContent narrows the selected content column to the projection, if any.
+func selectContent(query *gorm.DB, projection Projection) *gorm.DB {
+	if projection == nil {
+		return query
+	}
+	return query.Select(
+		"quickstarts.id, quickstarts.created_at, quickstarts.updated_at, quickstarts.deleted_at, quickstarts.name, quickstarts.source_file, quickstarts.source_revision, " +
+			"quickstarts.status, quickstarts.publish_at, quickstarts.unpublish_at, quickstarts.replacement, " +
+			projection.SQL(query.Dialector.Name(), "quickstarts.content") + " AS content",
+	)
+}
+
+// visibleSQL is the condition for rows of table that are served without
+// drafts; its two parameters are the current time.
+func visibleSQL(table string) string {
+	return fmt.Sprintf("%[1]s.status <> 'draft' AND (%[1]s.publish_at IS NULL OR %[1]s.publish_at <= ?) AND (%[1]s.unpublish_at IS NULL OR %[1]s.unpublish_at > ?)", table)
+}
+
+// onlyVisible leaves out unpublished rows of table unless includeDrafts is set.
+func onlyVisible(query *gorm.DB, table string, includeDrafts bool) *gorm.DB {
+	if includeDrafts {
+		return query
+	}
+	now := time.Now()
+	return query.Where(visibleSQL(table), now, now)
+}
+
+func (r *gormQuickstarts) FindByID(ctx context.Context, id int) (models.Quickstart, error) {
+	var quickStart models.Quickstart
+	err := withContext(r.db, ctx).First(&quickStart, id).Error
+	return quickStart, err
+}
+
+func (r *gormQuickstarts) Find(ctx context.Context, q QuickstartQuery) ([]models.Quickstart, error) {
+	db := onlyVisible(withContext(r.db, ctx), "quickstarts", q.IncludeDrafts)
+	var quickstarts []models.Quickstart
+
+	if q.Name != "" {
+		err := selectContent(db.Model(&models.Quickstart{}), q.Projection).Where("name = ?", q.Name).Find(&quickstarts).Error
+		return quickstarts, err
+	}
+	if len(q.TagTypes) > 0 {
+		return r.findByTagsAndDisplayName(db, q)
+	}
+
+	query := selectContent(db.Model(&models.Quickstart{}), q.Projection).Offset(q.Offset)
+	if q.DisplayName != "" {
+		query = query.Where("content->'spec'->>'displayName' ILIKE ?", "%"+q.DisplayName+"%")
+	}
+	// Apply limit only if it's not -1 (which means no limit)
+	if q.Limit != -1 {
+		query = query.Limit(q.Limit)
+	}
+	return quickstarts, query.Find(&quickstarts).Error
+}
+
+func (r *gormQuickstarts) ResolveAlias(ctx context.Context, name string) (string, error) {
+	var alias models.QuickstartAlias
+	if err := withContext(r.db, ctx).Where("name = ?", name).First(&alias).Error; err != nil {
+		return "", err
+	}
+	return alias.QuickstartName, nil
+}
+
+// findByTagsAndDisplayName finds quickstarts by tags and display name with pagination
+func (r *gormQuickstarts) findByTagsAndDisplayName(db *gorm.DB, q QuickstartQuery) ([]models.Quickstart, error) {
+	var quickstarts []models.Quickstart
+
+	// build "(t.type = ? AND t.value IN (?)) OR …" and collect params
+	conds := make([]string, len(q.TagTypes))
+	params := make([]interface{}, 0, len(q.TagTypes)*2)
+	for i, tt := range q.TagTypes {
+		conds[i] = "(t.type = ? AND t.value IN (?))"
+		params = append(params, tt, q.TagValues[i])
+	}
+	whereClause := strings.Join(conds, " OR ")
+
+	query := selectContent(db.Model(&models.Quickstart{}), q.Projection).
+		Joins("JOIN quickstart_tags qt ON qt.quickstart_id = quickstarts.id").
+		Joins("JOIN tags t ON t.id = qt.tag_id").
+		Where(whereClause, params...).
+		Group("quickstarts.id").
+		Having("COUNT(DISTINCT t.type) = ?", len(q.TagTypes))
+
+	if q.DisplayName != "" {
+		query = query.
+			Where("content->'spec'->>'displayName' ILIKE ?", "%"+q.DisplayName+"%")
+	}
+	query = query.Offset(q.Offset)
+	if q.Limit != -1 {
+		query = query.Limit(q.Limit)
+	}
+
+	return quickstarts, query.Find(&quickstarts).Error
+}
+
+// FindFuzzy supports optional tag filtering; leave q.TagTypes empty to search
+// the whole catalog.
+func (r *gormQuickstarts) FindFuzzy(ctx context.Context, q QuickstartQuery) ([]models.Quickstart, error) {
+	// Fuzzy search needs PostgreSQL with the fuzzystrmatch extension
+	if !r.fuzzySearch {
+		return nil, ErrFuzzySearchUnsupported
+	}
+	db := withContext(r.db, ctx)
+	var quickstarts []models.Quickstart
+
+	cfg := config.Get()
+	threshold := cfg.MaxFuzzySearchDistance
+
+	// Build the base query with optional tag filtering
+	var baseTableQuery string
+	var params []interface{}
+
+	// Start with searchTerm (used in query_words CTE)
+	params = append(params, q.DisplayName)
+
+	if len(q.TagTypes) > 0 {
+		// Build tag filter conditions
+		conds := make([]string, len(q.TagTypes))
+
+		// Add tag parameters BEFORE threshold
+		for i, tt := range q.TagTypes {
+			conds[i] = "(t.type = ? AND t.value IN (?))"
+			params = append(params, tt, q.TagValues[i])
+		}
+		whereClause := strings.Join(conds, " OR ")
+
+		// CTE that filters quickstarts by tags first
+		baseTableQuery = `
+		tagged_quickstarts AS (
+			SELECT q.id, q.created_at, q.updated_at, q.deleted_at, q.name, q.source_file, q.source_revision, q.status, q.publish_at, q.unpublish_at, q.replacement, q.content
+			FROM quickstarts q
+			JOIN quickstart_tags qt ON qt.quickstart_id = q.id
+			JOIN tags t ON t.id = qt.tag_id
+			WHERE ` + whereClause + `
+			GROUP BY q.id, q.created_at, q.updated_at, q.deleted_at, q.name, q.source_file, q.source_revision, q.status, q.publish_at, q.unpublish_at, q.replacement, q.content
+			HAVING COUNT(DISTINCT t.type) = ` + fmt.Sprintf("%d", len(q.TagTypes)) + `
+		),`
+	} else {
+		baseTableQuery = ""
+	}
+
+	// Determine which table to use in word_matches CTE
+	sourceTable := "quickstarts q"
+	sourceAlias := "q"
+	if len(q.TagTypes) > 0 {
+		sourceTable = "tagged_quickstarts tq"
+		sourceAlias = "tq"
+	}
+
+	// Leave out unpublished quickstarts, with the current time parameters
+	// after the tag parameters
+	visibleClause := ""
+	if !q.IncludeDrafts {
+		visibleClause = " AND " + visibleSQL(sourceAlias)
+		now := time.Now()
+		params = append(params, now, now)
+	}
+
+	// Add threshold AFTER tag and visibility parameters (used in WHERE min_distance <= ?)
+	params = append(params, threshold)
+
+	contentColumn := "content"
+	if q.Projection != nil {
+		contentColumn = q.Projection.SQL(db.Dialector.Name(), "content") + " AS content"
+	}
+
+	// Word-by-word fuzzy matching with partial matches:
+	// 1. Split query into words
+	// 2. For each query word, find the best matching word in each display name
+	// 3. Return quickstarts that match at least one query word within threshold
+	// 4. Order by: number of matching words (DESC), then total distance (ASC)
+	sqlQuery := `
+		WITH query_words AS (
+			SELECT unnest(regexp_split_to_array(LOWER(?), '\s+')) as query_word
+		),
+		` + baseTableQuery + `
+		word_matches AS (
+			SELECT
+				` + sourceAlias + `.id,
+				` + sourceAlias + `.created_at,
+				` + sourceAlias + `.updated_at,
+				` + sourceAlias + `.deleted_at,
+				` + sourceAlias + `.name,
+				` + sourceAlias + `.source_file,
+				` + sourceAlias + `.source_revision,
+				` + sourceAlias + `.status,
+				` + sourceAlias + `.publish_at,
+				` + sourceAlias + `.unpublish_at,
+				` + sourceAlias + `.replacement,
+				` + sourceAlias + `.content,
+				qw.query_word,
+				MIN(levenshtein(qw.query_word, display_word)) as min_distance
+			FROM query_words qw
+			CROSS JOIN ` + sourceTable + `
+			CROSS JOIN LATERAL unnest(regexp_split_to_array(LOWER(` + sourceAlias + `.content->'spec'->>'displayName'), '\s+')) as display_word
+			WHERE ` + sourceAlias + `.content->'spec'->>'displayName' IS NOT NULL` + visibleClause + `
+			GROUP BY ` + sourceAlias + `.id, ` + sourceAlias + `.created_at, ` + sourceAlias + `.updated_at, ` + sourceAlias + `.deleted_at, ` + sourceAlias + `.name, ` + sourceAlias + `.source_file, ` + sourceAlias + `.source_revision, ` +
+		sourceAlias + `.status, ` + sourceAlias + `.publish_at, ` + sourceAlias + `.unpublish_at, ` + sourceAlias + `.replacement, ` + sourceAlias + `.content, qw.query_word
+		)
+		SELECT
+			id, created_at, updated_at, deleted_at, name, source_file, source_revision, status, publish_at, unpublish_at, replacement, ` + contentColumn + `,
+			COUNT(*) as match_count,
+			SUM(min_distance) as total_distance
+		FROM word_matches
+		WHERE min_distance <= ?
+		GROUP BY id, created_at, updated_at, deleted_at, name, source_file, source_revision, status, publish_at, unpublish_at, replacement, content
+		ORDER BY match_count DESC, total_distance ASC, content->'spec'->>'displayName' ASC`
+
+	if q.Limit == -1 {
+		sqlQuery += ` OFFSET ?`
+		params = append(params, q.Offset)
+	} else {
+		sqlQuery += ` LIMIT ? OFFSET ?`
+		params = append(params, q.Limit, q.Offset)
+	}
+
+	// dbresolver only sends raw SQL starting with SELECT to a replica.
+	return quickstarts, db.Clauses(dbresolver.Read).Raw(sqlQuery, params...).Find(&quickstarts).Error
+}
@@ -0,0 +1,411 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+)
+
+// Memory is an in-memory fake of every repository, for tests that exercise
+// handlers and services without a database. Catalog content is added with
+// AddQuickstart and AddHelpTopic. Fuzzy search is not supported, so services
+// fall back to Find as they do on SQLite, and neither are archives nor the
+// seed run history.
+type Memory struct {
+	mu          sync.RWMutex
+	lastID      uint
+	quickstarts []models.Quickstart
+	helpTopics  []models.HelpTopic
+	tags        map[string]models.Tag
+	favorites   []models.FavoriteQuickstart
+	progress    []models.QuickstartProgress
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{tags: map[string]models.Tag{}}
+}
+
+// Repositories returns repositories backed by m.
+func (m *Memory) Repositories() Repositories {
+	return Repositories{
+		Quickstarts: memoryQuickstarts{m},
+		HelpTopics:  memoryHelpTopics{m},
+		Tags:        memoryTags{m},
+		Favorites:   memoryFavorites{m},
+		Progress:    memoryProgress{m},
+	}
+}
+
+func (m *Memory) nextID() uint {
+	m.lastID++
+	return m.lastID
+}
+
+// tag returns the stored tag with the type and value of t, creating it if needed.
+func (m *Memory) tag(t models.Tag) models.Tag {
+	key := string(t.Type) + "/" + t.Value
+	if stored, ok := m.tags[key]; ok {
+		return stored
+	}
+	t.ID = m.nextID()
+	t.Quickstarts = nil
+	t.HelpTopics = nil
+	m.tags[key] = t
+	return t
+}
+
+// AddQuickstart stores q with its tags and returns it with IDs assigned.
+func (m *Memory) AddQuickstart(q models.Quickstart) models.Quickstart {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q.ID = m.nextID()
+	q.CreatedAt = time.Now()
+	q.UpdatedAt = q.CreatedAt
+	tags := make([]models.Tag, len(q.Tags))
+	for i, t := range q.Tags {
+		tags[i] = m.tag(t)
+	}
+	q.Tags = tags
+	m.quickstarts = append(m.quickstarts, q)
+	return q
+}
+
+// AddHelpTopic stores h with its tags and returns it with IDs assigned.
+func (m *Memory) AddHelpTopic(h models.HelpTopic) models.HelpTopic {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h.ID = m.nextID()
+	h.CreatedAt = time.Now()
+	h.UpdatedAt = h.CreatedAt
+	tags := make([]models.Tag, len(h.Tags))
+	for i, t := range h.Tags {
+		tags[i] = m.tag(t)
+	}
+	h.Tags = tags
+	m.helpTopics = append(m.helpTopics, h)
+	return h
+}
+
+// hasTags reports whether every requested tag type has at least one matching
+// value, mirroring the HAVING COUNT(DISTINCT t.type) query.
+func hasTags(tags []models.Tag, tagTypes []models.TagType, tagValues [][]string) bool {
+	for i, tt := range tagTypes {
+		found := false
+		for _, t := range tags {
+			if t.Type != tt {
+				continue
+			}
+			for _, v := range tagValues[i] {
+				if t.Value == v {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit != -1 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+type memoryQuickstarts struct{ m *Memory }
+
+// result copies q the way a database query returns it: without
+// associations and with the projection applied.
+func (r memoryQuickstarts) result(q models.Quickstart, projection Projection) models.Quickstart {
+	q.Tags = nil
+	q.FavoriteQuickstart = nil
+	q.PreviousNames = nil
+	if projection != nil {
+		q.Content = projection.Apply(q.Content)
+	}
+	return q
+}
+
+func (r memoryQuickstarts) FindByID(_ context.Context, id int) (models.Quickstart, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	for _, q := range r.m.quickstarts {
+		if int(q.ID) == id {
+			return r.result(q, nil), nil
+		}
+	}
+	return models.Quickstart{}, ErrNotFound
+}
+
+func (r memoryQuickstarts) Find(_ context.Context, q QuickstartQuery) ([]models.Quickstart, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	now := time.Now()
+	result := []models.Quickstart{}
+	if q.Name != "" {
+		for _, stored := range r.m.quickstarts {
+			if stored.Name == q.Name && (q.IncludeDrafts || stored.Visible(now)) {
+				result = append(result, r.result(stored, q.Projection))
+			}
+		}
+		return result, nil
+	}
+
+	needle := strings.ToLower(q.DisplayName)
+	for _, stored := range r.m.quickstarts {
+		if !q.IncludeDrafts && !stored.Visible(now) {
+			continue
+		}
+		if !hasTags(stored.Tags, q.TagTypes, q.TagValues) {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(displayName(stored)), needle) {
+			continue
+		}
+		result = append(result, r.result(stored, q.Projection))
+	}
+	return paginate(result, q.Limit, q.Offset), nil
+}
+
+func (r memoryQuickstarts) FindFuzzy(context.Context, QuickstartQuery) ([]models.Quickstart, error) {
+	return nil, ErrFuzzySearchUnsupported
+}
+
+// ResolveAlias looks name up in the PreviousNames of the added quickstarts.
+func (r memoryQuickstarts) ResolveAlias(_ context.Context, name string) (string, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	for _, q := range r.m.quickstarts {
+		for _, previous := range q.PreviousNames {
+			if previous == name {
+				return q.Name, nil
+			}
+		}
+	}
+	return "", ErrNotFound
+}
+
+func displayName(q models.Quickstart) string {
+	var content struct {
+		Spec struct {
+			DisplayName string `json:"displayName"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(q.Content, &content); err != nil {
+		return ""
+	}
+	return content.Spec.DisplayName
+}
+
+type memoryHelpTopics struct{ m *Memory }
+
+func (r memoryHelpTopics) FindByName(_ context.Context, name string) (models.HelpTopic, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	for _, h := range r.m.helpTopics {
+		if h.Name == name {
+			h.Tags = nil
+			return h, nil
+		}
+	}
+	return models.HelpTopic{}, ErrNotFound
+}
+
+func (r memoryHelpTopics) Find(_ context.Context, f HelpTopicFilter, projection Projection) ([]models.HelpTopic, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	names := make(map[string]bool, len(f.Names))
+	for _, n := range f.Names {
+		names[n] = true
+	}
+	var tagTypes []models.TagType
+	var tagValues [][]string
+	for tt, values := range f.Tags {
+		if len(values) > 0 {
+			tagTypes = append(tagTypes, tt)
+			tagValues = append(tagValues, values)
+		}
+	}
+
+	now := time.Now()
+	result := []models.HelpTopic{}
+	for _, h := range r.m.helpTopics {
+		if !f.IncludeDrafts && !h.Visible(now) {
+			continue
+		}
+		if len(names) > 0 && !names[h.Name] {
+			continue
+		}
+		if !hasTags(h.Tags, tagTypes, tagValues) {
+			continue
+		}
+		h.Tags = nil
+		if projection != nil {
+			h.Content = projection.Apply(h.Content)
+		}
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+type memoryTags struct{ m *Memory }
+
+func (r memoryTags) Find(_ context.Context, kind models.TagType) ([]models.Tag, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	tags := []models.Tag{}
+	for _, t := range r.m.tags {
+		if kind == "" || t.Type == kind {
+			tags = append(tags, t)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Type != tags[j].Type {
+			return tags[i].Type < tags[j].Type
+		}
+		return tags[i].Value < tags[j].Value
+	})
+	return tags, nil
+}
+
+type memoryFavorites struct{ m *Memory }
+
+func (r memoryFavorites) FindFavorites(_ context.Context, accountId string) ([]models.FavoriteQuickstart, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	favorites := []models.FavoriteQuickstart{}
+	for _, f := range r.m.favorites {
+		if f.AccountId == accountId && f.Favorite {
+			favorites = append(favorites, f)
+		}
+	}
+	return favorites, nil
+}
+
+func (r memoryFavorites) Find(_ context.Context, accountId, quickstartName string) (models.FavoriteQuickstart, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	for _, f := range r.m.favorites {
+		if f.AccountId == accountId && f.QuickstartName == quickstartName {
+			return f, nil
+		}
+	}
+	return models.FavoriteQuickstart{}, ErrNotFound
+}
+
+func (r memoryFavorites) Create(_ context.Context, favorite *models.FavoriteQuickstart) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	exists := false
+	for _, q := range r.m.quickstarts {
+		if q.Name == favorite.QuickstartName {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		return fmt.Errorf("quickstart %q: %w", favorite.QuickstartName, ErrNotFound)
+	}
+	favorite.ID = r.m.nextID()
+	favorite.CreatedAt = time.Now()
+	favorite.UpdatedAt = favorite.CreatedAt
+	r.m.favorites = append(r.m.favorites, *favorite)
+	return nil
+}
+
+func (r memoryFavorites) SetFavorite(_ context.Context, favorite *models.FavoriteQuickstart, value bool) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	for i := range r.m.favorites {
+		if r.m.favorites[i].ID == favorite.ID {
+			r.m.favorites[i].Favorite = value
+			r.m.favorites[i].UpdatedAt = time.Now()
+			*favorite = r.m.favorites[i]
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+type memoryProgress struct{ m *Memory }
+
+func (r memoryProgress) Find(_ context.Context, f ProgressFilter) ([]models.QuickstartProgress, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	progresses := []models.QuickstartProgress{}
+	for _, p := range r.m.progress {
+		if f.AccountId != nil && *f.AccountId != 0 && p.AccountId != *f.AccountId {
+			continue
+		}
+		if f.QuickstartName != nil && *f.QuickstartName != "" && p.QuickstartName != *f.QuickstartName {
+			continue
+		}
+		progresses = append(progresses, p)
+	}
+	return progresses, nil
+}
+
+func (r memoryProgress) FindOne(_ context.Context, accountId int, quickstartName string) (models.QuickstartProgress, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+	for _, p := range r.m.progress {
+		if p.AccountId == accountId && p.QuickstartName == quickstartName {
+			return p, nil
+		}
+	}
+	return models.QuickstartProgress{}, ErrNotFound
+}
+
+func (r memoryProgress) Save(_ context.Context, progress *models.QuickstartProgress) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	now := time.Now()
+	if progress.ID == 0 {
+		for _, p := range r.m.progress {
+			if p.AccountId == progress.AccountId && p.QuickstartName == progress.QuickstartName {
+				return fmt.Errorf("progress for account %d and quickstart %q already exists", progress.AccountId, progress.QuickstartName)
+			}
+		}
+		progress.ID = r.m.nextID()
+		progress.CreatedAt = now
+		progress.UpdatedAt = now
+		r.m.progress = append(r.m.progress, *progress)
+		return nil
+	}
+	for i := range r.m.progress {
+		if r.m.progress[i].ID == progress.ID {
+			progress.UpdatedAt = now
+			r.m.progress[i] = *progress
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r memoryProgress) Delete(_ context.Context, id int) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	for i, p := range r.m.progress {
+		if int(p.ID) == id {
+			r.m.progress = append(r.m.progress[:i], r.m.progress[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
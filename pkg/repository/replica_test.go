@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestReadReplicaRouting uses two SQLite files holding different data to
+// check which database each repository reads from.
+func TestReadReplicaRouting(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	open := func(name string) *gorm.DB {
+		db, err := gorm.Open(sqlite.Open(filepath.Join(dir, name)), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.Tag{}, &models.Quickstart{}, &models.QuickstartProgress{}, &models.HelpTopic{}, &models.FavoriteQuickstart{}))
+		return db
+	}
+	primary := open("primary.db")
+	replica := open("replica.db")
+	require.NoError(t, primary.Create(&models.Quickstart{Name: "on-primary", Content: []byte(`{}`)}).Error)
+	require.NoError(t, replica.Create(&models.Quickstart{Name: "on-replica", Content: []byte(`{}`)}).Error)
+
+	require.NoError(t, database.UseReadReplicas(primary, sqlite.Open(filepath.Join(dir, "replica.db"))))
+	repos := NewGorm(primary, false)
+
+	t.Run("catalog reads use the replica", func(t *testing.T) {
+		result, err := repos.Quickstarts.Find(ctx, QuickstartQuery{Limit: -1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"on-replica"}, quickstartNames(result))
+	})
+
+	t.Run("favorites use the primary", func(t *testing.T) {
+		missing := models.FavoriteQuickstart{AccountId: "replica-account", QuickstartName: "on-replica", Favorite: true}
+		assert.ErrorIs(t, repos.Favorites.Create(ctx, &missing), ErrNotFound)
+
+		favorite := models.FavoriteQuickstart{AccountId: "replica-account", QuickstartName: "on-primary", Favorite: true}
+		require.NoError(t, repos.Favorites.Create(ctx, &favorite))
+		favorites, err := repos.Favorites.FindFavorites(ctx, "replica-account")
+		require.NoError(t, err)
+		assert.Len(t, favorites, 1, "a favorite is visible right after it is written")
+	})
+
+	t.Run("progress uses the primary", func(t *testing.T) {
+		progress := models.QuickstartProgress{AccountId: 99, QuickstartName: "on-primary"}
+		require.NoError(t, repos.Progress.Save(ctx, &progress))
+		found, err := repos.Progress.FindOne(ctx, 99, "on-primary")
+		require.NoError(t, err)
+		assert.Equal(t, progress.ID, found.ID)
+
+		var onReplica int64
+		require.NoError(t, replica.Model(&models.QuickstartProgress{}).Count(&onReplica).Error)
+		assert.Zero(t, onReplica)
+	})
+}
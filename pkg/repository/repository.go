@@ -0,0 +1,135 @@
+// Package repository defines the data access interfaces used by the services,
+// with a GORM implementation for production and an in-memory fake for tests.
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when a looked up record does not exist. It is the
+// GORM sentinel, so utils.LookupError and the problem mapping recognize it
+// regardless of the implementation.
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// ErrFuzzySearchUnsupported is returned by QuickstartRepository.FindFuzzy when
+// the backend cannot rank by edit distance. Callers fall back to Find.
+var ErrFuzzySearchUnsupported = errors.New("fuzzy search is not supported")
+
+// Projection narrows the content returned by list queries. It is implemented
+// by services.ContentProjection.
+type Projection interface {
+	// SQL renders the projection as an expression over column.
+	SQL(dialect, column string) string
+	// Apply projects already loaded content.
+	Apply(content datatypes.JSON) datatypes.JSON
+}
+
+// QuickstartQuery selects quickstarts. Every tag type must match at least one
+// of its values. A Limit of -1 means no limit. Drafts and quickstarts outside
+// their publishing window are left out unless IncludeDrafts is set.
+type QuickstartQuery struct {
+	TagTypes      []models.TagType
+	TagValues     [][]string
+	Name          string
+	DisplayName   string
+	Limit         int
+	Offset        int
+	Projection    Projection
+	IncludeDrafts bool
+}
+
+// HelpTopicFilter holds any combination of name‐ and tag‐based filters. Like
+// QuickstartQuery, it leaves out unpublished help topics unless IncludeDrafts
+// is set.
+type HelpTopicFilter struct {
+	Names         []string
+	Tags          map[models.TagType][]string
+	IncludeDrafts bool
+}
+
+// ProgressFilter selects progress records. Nil or zero fields match every
+// record.
+type ProgressFilter struct {
+	AccountId      *int
+	QuickstartName *string
+}
+
+// QuickstartRepository reads the quickstart catalog.
+type QuickstartRepository interface {
+	FindByID(ctx context.Context, id int) (models.Quickstart, error)
+	// Find filters by exact name, or else by tags and a case-insensitive
+	// display name substring.
+	Find(ctx context.Context, q QuickstartQuery) ([]models.Quickstart, error)
+	// FindFuzzy ranks quickstarts by the edit distance between the words of
+	// q.DisplayName and their display names.
+	FindFuzzy(ctx context.Context, q QuickstartQuery) ([]models.Quickstart, error)
+	// ResolveAlias returns the current name of the quickstart previously
+	// called name, or ErrNotFound if no quickstart was.
+	ResolveAlias(ctx context.Context, name string) (string, error)
+}
+
+// HelpTopicRepository reads the help topic catalog.
+type HelpTopicRepository interface {
+	FindByName(ctx context.Context, name string) (models.HelpTopic, error)
+	Find(ctx context.Context, f HelpTopicFilter, projection Projection) ([]models.HelpTopic, error)
+}
+
+// TagRepository reads the tags attached to catalog content.
+type TagRepository interface {
+	// Find returns the tags of kind, or every tag when kind is empty.
+	Find(ctx context.Context, kind models.TagType) ([]models.Tag, error)
+}
+
+// FavoriteRepository stores the quickstarts users marked as favorite.
+type FavoriteRepository interface {
+	// FindFavorites returns the records of accountId marked as favorite.
+	FindFavorites(ctx context.Context, accountId string) ([]models.FavoriteQuickstart, error)
+	Find(ctx context.Context, accountId, quickstartName string) (models.FavoriteQuickstart, error)
+	// Create stores a new record. It fails with ErrNotFound if the quickstart
+	// does not exist.
+	Create(ctx context.Context, favorite *models.FavoriteQuickstart) error
+	SetFavorite(ctx context.Context, favorite *models.FavoriteQuickstart, value bool) error
+}
+
+// ProgressRepository stores users' quickstart progress.
+type ProgressRepository interface {
+	Find(ctx context.Context, f ProgressFilter) ([]models.QuickstartProgress, error)
+	FindOne(ctx context.Context, accountId int, quickstartName string) (models.QuickstartProgress, error)
+	// Save creates progress without an ID and updates it otherwise.
+	Save(ctx context.Context, progress *models.QuickstartProgress) error
+	Delete(ctx context.Context, id int) error
+}
+
+// ArchiveRepository exports and imports content archives (see package archive).
+type ArchiveRepository interface {
+	Export(ctx context.Context, w io.Writer, opts archive.Options) (archive.Counts, error)
+	// Import upserts the archive read from r. Errors caused by malformed
+	// archives wrap archive.ErrInvalid.
+	Import(ctx context.Context, r io.Reader, opts archive.Options) (archive.Counts, error)
+}
+
+// SeedRunRepository reads the history of content seeding runs.
+type SeedRunRepository interface {
+	// Find returns seed runs, newest first. A limit of -1 returns every run.
+	Find(ctx context.Context, limit, offset int) ([]models.SeedRun, error)
+}
+
+// Repositories bundles the repositories the API depends on.
+type Repositories struct {
+	Quickstarts QuickstartRepository
+	HelpTopics  HelpTopicRepository
+	Tags        TagRepository
+	Favorites   FavoriteRepository
+	Progress    ProgressRepository
+	// Archive is nil when the backend does not support archives.
+	Archive ArchiveRepository
+	// SeedRuns is nil when the backend keeps no seed run history.
+	SeedRuns SeedRunRepository
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+// store is a repository implementation under test plus a way to add the
+// catalog content that only the seeding process writes.
+type store struct {
+	repos         Repositories
+	addQuickstart func(t *testing.T, q models.Quickstart) models.Quickstart
+	addHelpTopic  func(t *testing.T, h models.HelpTopic) models.HelpTopic
+	// ilike reports whether display name filters work; SQLite has no ILIKE.
+	ilike bool
+	fuzzy bool
+}
+
+func gormStore(t *testing.T) store {
+	tags := func(t *testing.T, tags []models.Tag) []models.Tag {
+		stored := make([]models.Tag, len(tags))
+		for i, tag := range tags {
+			require.NoError(t, database.DB.Where("type = ? AND value = ?", tag.Type, tag.Value).FirstOrCreate(&tag).Error)
+			stored[i] = tag
+		}
+		return stored
+	}
+	return store{
+		repos: NewGorm(database.DB, database.IsFuzzySearchSupported()),
+		addQuickstart: func(t *testing.T, q models.Quickstart) models.Quickstart {
+			q.Tags = tags(t, q.Tags)
+			require.NoError(t, database.DB.Create(&q).Error)
+			for _, previous := range q.PreviousNames {
+				require.NoError(t, database.DB.Create(&models.QuickstartAlias{Name: previous, QuickstartName: q.Name}).Error)
+			}
+			return q
+		},
+		addHelpTopic: func(t *testing.T, h models.HelpTopic) models.HelpTopic {
+			h.Tags = tags(t, h.Tags)
+			require.NoError(t, database.DB.Create(&h).Error)
+			return h
+		},
+		ilike: database.DB.Dialector.Name() == "postgres",
+		fuzzy: database.IsFuzzySearchSupported(),
+	}
+}
+
+func memoryStore(*testing.T) store {
+	m := NewMemory()
+	return store{
+		repos: m.Repositories(),
+		addQuickstart: func(_ *testing.T, q models.Quickstart) models.Quickstart {
+			return m.AddQuickstart(q)
+		},
+		addHelpTopic: func(_ *testing.T, h models.HelpTopic) models.HelpTopic {
+			return m.AddHelpTopic(h)
+		},
+		ilike: true,
+	}
+}
+
+func quickstartNames(items []models.Quickstart) []string {
+	names := make([]string, len(items))
+	for i, q := range items {
+		names[i] = q.Name
+	}
+	return names
+}
+
+// TestRepositories runs the same expectations against the GORM
+// implementation and the in-memory fake, so tests written against the fake
+// hold for the database too.
+func TestRepositories(t *testing.T) {
+	for name, newStore := range map[string]func(*testing.T) store{
+		"gorm":   gormStore,
+		"memory": memoryStore,
+	} {
+		t.Run(name, func(t *testing.T) {
+			testRepositories(t, newStore(t))
+		})
+	}
+}
+
+func testRepositories(t *testing.T, s store) {
+	ctx := context.Background()
+	rhel := models.Tag{Type: models.BundleTag, Value: "repo-rhel"}
+	settings := models.Tag{Type: models.BundleTag, Value: "repo-settings"}
+	iam := models.Tag{Type: models.ProductFamilies, Value: "repo-iam"}
+
+	first := s.addQuickstart(t, models.Quickstart{Name: "repo-first", Content: []byte(`{"spec":{"displayName":"First Steps"}}`), Tags: []models.Tag{rhel, iam}})
+	s.addQuickstart(t, models.Quickstart{Name: "repo-second", Content: []byte(`{"spec":{"displayName":"Second Steps"}}`), Tags: []models.Tag{settings}, PreviousNames: []string{"repo-second-old"}})
+	s.addQuickstart(t, models.Quickstart{Name: "repo-third", Content: []byte(`{"spec":{"displayName":"Something Else"}}`), Tags: []models.Tag{rhel}})
+	s.addHelpTopic(t, models.HelpTopic{Name: "repo-topic-a", GroupName: "repo", Content: []byte(`{}`), Tags: []models.Tag{rhel}})
+	s.addHelpTopic(t, models.HelpTopic{Name: "repo-topic-b", GroupName: "repo", Content: []byte(`{}`), Tags: []models.Tag{settings}})
+	past, future := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.addQuickstart(t, models.Quickstart{Name: "repo-draft", Content: []byte(`{}`), Tags: []models.Tag{rhel}, Lifecycle: models.Lifecycle{Status: models.StatusDraft}})
+	s.addQuickstart(t, models.Quickstart{Name: "repo-scheduled", Content: []byte(`{}`), Tags: []models.Tag{rhel}, Lifecycle: models.Lifecycle{Status: models.StatusPublished, PublishAt: &future}})
+	s.addQuickstart(t, models.Quickstart{Name: "repo-retired", Content: []byte(`{}`), Lifecycle: models.Lifecycle{Status: models.StatusDeprecated, UnpublishAt: &past}})
+	s.addHelpTopic(t, models.HelpTopic{Name: "repo-topic-draft", GroupName: "repo", Content: []byte(`{}`), Tags: []models.Tag{settings}, Lifecycle: models.Lifecycle{Status: models.StatusDraft}})
+
+	t.Run("quickstarts by id", func(t *testing.T) {
+		q, err := s.repos.Quickstarts.FindByID(ctx, int(first.ID))
+		require.NoError(t, err)
+		assert.Equal(t, "repo-first", q.Name)
+		assert.Empty(t, q.Tags, "associations are not loaded")
+
+		_, err = s.repos.Quickstarts.FindByID(ctx, 999999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("quickstarts by name", func(t *testing.T) {
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{Name: "repo-third", Limit: 50})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"repo-third"}, quickstartNames(result))
+	})
+
+	t.Run("quickstart aliases", func(t *testing.T) {
+		name, err := s.repos.Quickstarts.ResolveAlias(ctx, "repo-second-old")
+		require.NoError(t, err)
+		assert.Equal(t, "repo-second", name)
+
+		_, err = s.repos.Quickstarts.ResolveAlias(ctx, "repo-second")
+		assert.ErrorIs(t, err, ErrNotFound, "current names are not aliases")
+
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{Name: "repo-second-old", Limit: 50})
+		require.NoError(t, err)
+		assert.Empty(t, result, "Find matches current names only")
+	})
+
+	t.Run("quickstarts by tags", func(t *testing.T) {
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{
+			TagTypes:  []models.TagType{models.BundleTag, models.ProductFamilies},
+			TagValues: [][]string{{"repo-rhel", "repo-settings"}, {"repo-iam"}},
+			Limit:     -1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"repo-first"}, quickstartNames(result))
+
+		result, err = s.repos.Quickstarts.Find(ctx, QuickstartQuery{
+			TagTypes:  []models.TagType{models.BundleTag},
+			TagValues: [][]string{{"repo-rhel"}},
+			Limit:     -1,
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"repo-first", "repo-third"}, quickstartNames(result))
+	})
+
+	t.Run("quickstarts by display name", func(t *testing.T) {
+		if !s.ilike {
+			t.Skip("display name filters need ILIKE")
+		}
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{DisplayName: "steps", Limit: -1})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"repo-first", "repo-second"}, quickstartNames(result))
+	})
+
+	t.Run("quickstart pagination", func(t *testing.T) {
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{Limit: 2, Offset: 0})
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		result, err = s.repos.Quickstarts.Find(ctx, QuickstartQuery{Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("fuzzy search support", func(t *testing.T) {
+		if s.fuzzy {
+			t.Skip("backend supports fuzzy search")
+		}
+		_, err := s.repos.Quickstarts.FindFuzzy(ctx, QuickstartQuery{DisplayName: "frist", Limit: -1})
+		assert.ErrorIs(t, err, ErrFuzzySearchUnsupported)
+	})
+
+	t.Run("unpublished content", func(t *testing.T) {
+		result, err := s.repos.Quickstarts.Find(ctx, QuickstartQuery{
+			TagTypes:      []models.TagType{models.BundleTag},
+			TagValues:     [][]string{{"repo-rhel"}},
+			IncludeDrafts: true,
+			Limit:         -1,
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"repo-first", "repo-third", "repo-draft", "repo-scheduled"}, quickstartNames(result))
+
+		result, err = s.repos.Quickstarts.Find(ctx, QuickstartQuery{Name: "repo-retired", Limit: 50})
+		require.NoError(t, err)
+		assert.Empty(t, result, "unpublished items are hidden by name too")
+
+		result, err = s.repos.Quickstarts.Find(ctx, QuickstartQuery{Name: "repo-retired", IncludeDrafts: true, Limit: 50})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Deprecated())
+
+		topics, err := s.repos.HelpTopics.Find(ctx, HelpTopicFilter{
+			Tags:          map[models.TagType][]string{models.BundleTag: {"repo-settings"}},
+			IncludeDrafts: true,
+		}, nil)
+		require.NoError(t, err)
+		assert.Len(t, topics, 2)
+	})
+
+	t.Run("help topics", func(t *testing.T) {
+		result, err := s.repos.HelpTopics.Find(ctx, HelpTopicFilter{
+			Tags: map[models.TagType][]string{models.BundleTag: {"repo-settings"}},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "repo-topic-b", result[0].Name)
+
+		result, err = s.repos.HelpTopics.Find(ctx, HelpTopicFilter{Names: []string{"repo-topic-a", "missing"}}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "repo-topic-a", result[0].Name)
+
+		topic, err := s.repos.HelpTopics.FindByName(ctx, "repo-topic-a")
+		require.NoError(t, err)
+		assert.Equal(t, "repo", topic.GroupName)
+
+		_, err = s.repos.HelpTopics.FindByName(ctx, "missing")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		tags, err := s.repos.Tags.Find(ctx, models.BundleTag)
+		require.NoError(t, err)
+		values := make([]string, len(tags))
+		for i, tag := range tags {
+			values[i] = tag.Value
+		}
+		assert.Equal(t, []string{"repo-rhel", "repo-settings"}, values)
+
+		all, err := s.repos.Tags.Find(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("favorites", func(t *testing.T) {
+		missing := models.FavoriteQuickstart{AccountId: "repo-account", QuickstartName: "missing", Favorite: true}
+		assert.ErrorIs(t, s.repos.Favorites.Create(ctx, &missing), ErrNotFound)
+
+		favorite := models.FavoriteQuickstart{AccountId: "repo-account", QuickstartName: "repo-first", Favorite: true}
+		require.NoError(t, s.repos.Favorites.Create(ctx, &favorite))
+		assert.NotZero(t, favorite.ID)
+
+		favorites, err := s.repos.Favorites.FindFavorites(ctx, "repo-account")
+		require.NoError(t, err)
+		assert.Len(t, favorites, 1)
+
+		found, err := s.repos.Favorites.Find(ctx, "repo-account", "repo-first")
+		require.NoError(t, err)
+		require.NoError(t, s.repos.Favorites.SetFavorite(ctx, &found, false))
+		assert.False(t, found.Favorite)
+
+		favorites, err = s.repos.Favorites.FindFavorites(ctx, "repo-account")
+		require.NoError(t, err)
+		assert.Empty(t, favorites, "unfavorited records are not listed")
+
+		_, err = s.repos.Favorites.Find(ctx, "repo-account", "repo-second")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		data := datatypes.JSON(`{"step":1}`)
+		progress := models.QuickstartProgress{AccountId: 4242, QuickstartName: "repo-first", Progress: &data}
+		require.NoError(t, s.repos.Progress.Save(ctx, &progress))
+		require.NotZero(t, progress.ID)
+
+		other := models.QuickstartProgress{AccountId: 4343, QuickstartName: "repo-first"}
+		require.NoError(t, s.repos.Progress.Save(ctx, &other))
+
+		found, err := s.repos.Progress.FindOne(ctx, 4242, "repo-first")
+		require.NoError(t, err)
+		assert.Equal(t, progress.ID, found.ID)
+
+		_, err = s.repos.Progress.FindOne(ctx, 4242, "repo-second")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		updated := datatypes.JSON(`{"step":2}`)
+		found.Progress = &updated
+		require.NoError(t, s.repos.Progress.Save(ctx, &found))
+
+		account := 4242
+		result, err := s.repos.Progress.Find(ctx, ProgressFilter{AccountId: &account})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.JSONEq(t, `{"step":2}`, string(*result[0].Progress))
+
+		name := "repo-first"
+		result, err = s.repos.Progress.Find(ctx, ProgressFilter{QuickstartName: &name})
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		require.NoError(t, s.repos.Progress.Delete(ctx, int(progress.ID)))
+		assert.ErrorIs(t, s.repos.Progress.Delete(ctx, int(progress.ID)), ErrNotFound)
+
+		result, err = s.repos.Progress.Find(ctx, ProgressFilter{})
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/securitylog"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+	"github.com/sirupsen/logrus"
+)
+
+// maxArchiveSize bounds the body of POST /admin/import.
+const maxArchiveSize = 256 * 1024 * 1024 // 256MB
+
+// authorizeAdmin reports whether the request comes from an associate holding
+// one of the configured admin roles. Otherwise it writes a 404 when the admin
+// API is disabled, or a 403, and returns false.
+func (s *ServerAdapter) authorizeAdmin(w http.ResponseWriter, r *http.Request, resource string) bool {
+	if len(s.adminRoles) == 0 {
+		utils.ErrorResponse(w, r, utils.NotFoundError("admin API is not enabled"))
+		return false
+	}
+	id, ok := r.Context().Value(identity.Key).(identity.XRHID)
+	if ok && id.Identity.Type == "Associate" {
+		for _, role := range id.Identity.Associate.Role {
+			if slices.Contains(s.adminRoles, role) {
+				return true
+			}
+		}
+	}
+	securitylog.LogWithReason(r.Context(), "AUTHORIZE", "admin_api", resource, "failure", "missing admin role")
+	utils.ErrorResponse(w, r, utils.ForbiddenError("an admin role is required"))
+	return false
+}
+
+// GetAdminExport handles GET /admin/export
+func (s *ServerAdapter) GetAdminExport(w http.ResponseWriter, r *http.Request, params generated.GetAdminExportParams) {
+	if !s.authorizeAdmin(w, r, "export") {
+		return
+	}
+	if !s.archiveService.Enabled() {
+		utils.ErrorResponse(w, r, utils.NotFoundError("archives are not supported"))
+		return
+	}
+	opts := archive.Options{UserData: params.UserData != nil && *params.UserData}
+
+	w.Header().Set("Content-Type", archive.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="quickstarts-%s.jsonl"`, time.Now().UTC().Format("20060102T150405Z")))
+	out := &trackingWriter{ResponseWriter: w}
+	counts, err := s.archiveService.WithContext(r.Context()).Export(out, opts)
+	if err != nil {
+		securitylog.LogWithReason(r.Context(), "READ", "content_archive", "export", "failure", err.Error())
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			utils.ErrorResponse(w, r, err)
+			return
+		}
+		// The archive is streamed, so the status has already been sent.
+		// Abort the connection so the client cannot mistake a truncated
+		// archive for a complete one.
+		logrus.WithContext(r.Context()).WithError(err).Error("Content export failed mid-stream")
+		panic(http.ErrAbortHandler)
+	}
+	securitylog.Log(r.Context(), "READ", "content_archive", "export", "success")
+	logrus.WithContext(r.Context()).WithFields(logrus.Fields{
+		"user_data":   opts.UserData,
+		"quickstarts": counts.Quickstarts,
+		"help_topics": counts.HelpTopics,
+		"aliases":     counts.Aliases,
+		"favorites":   counts.Favorites,
+		"progress":    counts.Progress,
+	}).Info("Exported content archive")
+}
+
+// PostAdminImport handles POST /admin/import
+func (s *ServerAdapter) PostAdminImport(w http.ResponseWriter, r *http.Request, params generated.PostAdminImportParams) {
+	if !s.authorizeAdmin(w, r, "import") {
+		return
+	}
+	if !s.archiveService.Enabled() {
+		utils.ErrorResponse(w, r, utils.NotFoundError("archives are not supported"))
+		return
+	}
+	// User data is opt-in, as on export, so a catalog promotion cannot
+	// overwrite the favorites and progress of the target environment.
+	opts := archive.Options{UserData: params.UserData != nil && *params.UserData}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxArchiveSize)
+	counts, err := s.archiveService.WithContext(r.Context()).Import(r.Body, opts)
+	if err != nil {
+		securitylog.LogWithReason(r.Context(), "UPDATE", "content_archive", "import", "failure", err.Error())
+		if errors.Is(err, archive.ErrInvalid) {
+			err = utils.ValidationError(err.Error())
+		}
+		utils.ErrorResponse(w, r, err)
+		return
+	}
+	securitylog.Log(r.Context(), "UPDATE", "content_archive", "import", "success")
+
+	utils.DataResponse(w, http.StatusOK, generated.ArchiveCounts{
+		Tags:        counts.Tags,
+		Quickstarts: counts.Quickstarts,
+		HelpTopics:  counts.HelpTopics,
+		Aliases:     counts.Aliases,
+		Favorites:   counts.Favorites,
+		Progress:    counts.Progress,
+		Skipped:     counts.Skipped,
+	})
+}
+
+// trackingWriter records whether anything has been written to the response.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// GetAdminSeedRuns handles GET /admin/seed-runs
+func (s *ServerAdapter) GetAdminSeedRuns(w http.ResponseWriter, r *http.Request, params generated.GetAdminSeedRunsParams) {
+	if !s.authorizeAdmin(w, r, "seed_runs") {
+		return
+	}
+	if !s.seedRunService.Enabled() {
+		utils.ErrorResponse(w, r, utils.NotFoundError("seed run history is not supported"))
+		return
+	}
+
+	limit := sanitizeLimit(utils.ConvertIntPtr(params.Limit, 50))
+	offset := sanitizeOffset(utils.ConvertIntPtr(params.Offset, 0))
+	runs, err := s.seedRunService.WithContext(r.Context()).Find(limit, offset)
+	if err != nil {
+		utils.ErrorResponse(w, r, err)
+		return
+	}
+	utils.StreamDataResponse(w, http.StatusOK, nil, runs, models.SeedRun.ToAPI)
+}
@@ -0,0 +1,200 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminRouter(t *testing.T, roles ...string) *chi.Mux {
+	t.Helper()
+	cfg := config.Get()
+	previous := cfg.AdminRoles
+	cfg.AdminRoles = roles
+	t.Cleanup(func() { cfg.AdminRoles = previous })
+
+	r := chi.NewRouter()
+	generated.HandlerFromMux(NewServerAdapter(testRepos), r)
+	return r
+}
+
+func withAssociate(r *http.Request, roles ...string) *http.Request {
+	id := identity.XRHID{Identity: identity.Identity{Type: "Associate", Associate: identity.Associate{Role: roles}}}
+	return r.WithContext(context.WithValue(r.Context(), identity.Key, id))
+}
+
+func TestAdminArchive(t *testing.T) {
+	t.Run("should return 404 when no admin roles are configured", func(t *testing.T) {
+		router := setupAdminRouter(t)
+		request := withAssociate(httptest.NewRequest(http.MethodGet, "/admin/export", nil), "quickstarts-admin")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("should return 403 without an admin role", func(t *testing.T) {
+		router := setupAdminRouter(t, "quickstarts-admin")
+		for _, request := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/admin/export", nil),
+			withAssociate(httptest.NewRequest(http.MethodGet, "/admin/export", nil), "other"),
+			withAssociate(httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader("")), "other"),
+		} {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusForbidden, response.Code)
+			var problem ProblemResponsePayload
+			json.NewDecoder(response.Body).Decode(&problem)
+			assert.Equal(t, "urn:quickstarts:problem:forbidden", problem.Type)
+		}
+	})
+
+	t.Run("should export and import an archive", func(t *testing.T) {
+		router := setupAdminRouter(t, "quickstarts-admin")
+		qs := models.Quickstart{Name: "archive-qs", Content: []byte(`{"spec":{"displayName":"Archived"}}`)}
+		require.NoError(t, database.DB.Create(&qs).Error)
+		t.Cleanup(func() {
+			database.DB.Unscoped().Where("name = ?", qs.Name).Delete(&models.Quickstart{})
+			// Imports mark the content as seeded; other tests expect no marker.
+			database.DB.Unscoped().Delete(&models.SeedGeneration{ID: models.SeedGenerationID})
+		})
+
+		request := withAssociate(httptest.NewRequest(http.MethodGet, "/admin/export", nil), "quickstarts-admin")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/x-ndjson", response.Header().Get("Content-Type"))
+		assert.Contains(t, response.Header().Get("Content-Disposition"), "attachment")
+		assert.Contains(t, response.Body.String(), `"name":"archive-qs"`)
+		exported := response.Body.Bytes()
+
+		require.NoError(t, database.DB.Model(&qs).Update("content", `{}`).Error)
+
+		request = withAssociate(httptest.NewRequest(http.MethodPost, "/admin/import", bytes.NewReader(exported)), "quickstarts-admin")
+		request.Header.Set("Content-Type", "application/x-ndjson")
+		response = httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+		var payload struct {
+			Data generated.ArchiveCounts
+		}
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&payload))
+		assert.GreaterOrEqual(t, payload.Data.Quickstarts, 1)
+
+		var restored models.Quickstart
+		require.NoError(t, database.DB.Where("name = ?", qs.Name).First(&restored).Error)
+		assert.JSONEq(t, `{"spec":{"displayName":"Archived"}}`, string(restored.Content))
+	})
+
+	t.Run("should import user data only when asked", func(t *testing.T) {
+		router := setupAdminRouter(t, "quickstarts-admin")
+		input := `{"kind":"header","header":{"version":2,"userData":true}}
+{"kind":"quickstart","quickstart":{"name":"archive-user-qs","content":{},"status":"published"}}
+{"kind":"favorite","favorite":{"accountId":"archive-user","quickstartName":"archive-user-qs","favorite":true}}
+`
+		t.Cleanup(func() {
+			database.DB.Unscoped().Where("account_id = ?", "archive-user").Delete(&models.FavoriteQuickstart{})
+			database.DB.Unscoped().Where("name = ?", "archive-user-qs").Delete(&models.Quickstart{})
+			database.DB.Unscoped().Delete(&models.SeedGeneration{ID: models.SeedGenerationID})
+		})
+
+		for _, tt := range []struct {
+			target    string
+			favorites int
+		}{
+			{"/admin/import", 0},
+			{"/admin/import?userData=true", 1},
+		} {
+			request := withAssociate(httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(input)), "quickstarts-admin")
+			request.Header.Set("Content-Type", "application/x-ndjson")
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+			require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+			var payload struct {
+				Data generated.ArchiveCounts
+			}
+			require.NoError(t, json.NewDecoder(response.Body).Decode(&payload))
+			assert.Equal(t, tt.favorites, payload.Data.Favorites, tt.target)
+		}
+	})
+
+	t.Run("should reject an invalid archive", func(t *testing.T) {
+		router := setupAdminRouter(t, "quickstarts-admin")
+		request := withAssociate(httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader(`{"kind":"tag"}`)), "quickstarts-admin")
+		request.Header.Set("Content-Type", "application/x-ndjson")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		var problem ProblemResponsePayload
+		json.NewDecoder(response.Body).Decode(&problem)
+		assert.Contains(t, problem.Detail, "must start with a header")
+	})
+}
+
+func TestAdminSeedRuns(t *testing.T) {
+	router := setupAdminRouter(t, "quickstarts-admin")
+	older := models.SeedRun{StartedAt: time.Now().Add(-time.Hour), FinishedAt: time.Now().Add(-time.Hour), Status: models.SeedRunSucceeded, Source: "docs", Updated: 3}
+	newer := models.SeedRun{StartedAt: time.Now(), FinishedAt: time.Now(), Status: models.SeedRunFailed, Source: "docs", Error: "boom",
+		Errors: []models.SeedItemError{{Kind: "quickstart", File: "quickstarts/bad/bad.yml", Error: "invalid yaml"}}}
+	require.NoError(t, database.DB.Create(&older).Error)
+	require.NoError(t, database.DB.Create(&newer).Error)
+	t.Cleanup(func() { database.DB.Where("id IN ?", []uint{older.ID, newer.ID}).Delete(&models.SeedRun{}) })
+
+	type responsePayload struct {
+		Data []generated.SeedRun
+	}
+
+	t.Run("should list runs newest first", func(t *testing.T) {
+		request := withAssociate(httptest.NewRequest(http.MethodGet, "/admin/seed-runs", nil), "quickstarts-admin")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		var payload responsePayload
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&payload))
+		require.Len(t, payload.Data, 2)
+		assert.Equal(t, generated.SeedRunStatus("failed"), payload.Data[0].Status)
+		assert.Equal(t, "boom", *payload.Data[0].Error)
+		assert.Equal(t, []generated.SeedItemError{{Kind: "quickstart", File: "quickstarts/bad/bad.yml", Error: "invalid yaml"}}, payload.Data[0].Errors)
+		assert.Equal(t, 3, payload.Data[1].Updated)
+		assert.Empty(t, payload.Data[1].Errors)
+	})
+
+	t.Run("should paginate", func(t *testing.T) {
+		request := withAssociate(httptest.NewRequest(http.MethodGet, "/admin/seed-runs?limit=1&offset=1", nil), "quickstarts-admin")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		var payload responsePayload
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&payload))
+		require.Len(t, payload.Data, 1)
+		assert.Equal(t, int(older.ID), payload.Data[0].Id)
+	})
+
+	t.Run("should return 403 without an admin role", func(t *testing.T) {
+		request := withAssociate(httptest.NewRequest(http.MethodGet, "/admin/seed-runs", nil), "other")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/RedHatInsights/quickstarts/pkg/services"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+)
+
+// contentProjection resolves the fields and view query parameters into a
+// content projection. Explicit fields win over view; view=full or no
+// parameters at all returns nil, meaning the full content.
+func contentProjection(fields *[]string, view string, summary func() *services.ContentProjection) (*services.ContentProjection, error) {
+	if requested := utils.ConvertStringSlice(fields); len(requested) > 0 {
+		return services.NewContentProjection(requested)
+	}
+	if view == "summary" {
+		return summary(), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuickstartsSparseFieldsets(t *testing.T) {
+	q := models.Quickstart{
+		Name:    "sparse-fieldsets",
+		Content: []byte(`{"metadata":{"name":"sparse-fieldsets"},"spec":{"displayName":"Sparse","description":"D","tasks":[{"title":"t"}]}}`),
+	}
+	database.DB.Create(&q)
+	// Other tests in this package count every quickstart in the table.
+	defer database.DB.Unscoped().Delete(&q)
+
+	r := chi.NewRouter()
+	generated.HandlerFromMux(NewServerAdapter(testRepos), r)
+
+	get := func(url string) (*httptest.ResponseRecorder, []map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var payload struct {
+			Data []struct {
+				Content map[string]interface{} `json:"content"`
+			} `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&payload)
+		contents := make([]map[string]interface{}, len(payload.Data))
+		for i, d := range payload.Data {
+			contents[i] = d.Content
+		}
+		return w, contents
+	}
+
+	t.Run("view=summary drops task bodies", func(t *testing.T) {
+		w, contents := get("/quickstarts?name=sparse-fieldsets&view=summary")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, contents, 1)
+		spec := contents[0]["spec"].(map[string]interface{})
+		assert.Equal(t, "Sparse", spec["displayName"])
+		assert.NotContains(t, spec, "tasks")
+	})
+
+	t.Run("fields selects explicit paths", func(t *testing.T) {
+		w, contents := get("/quickstarts?name=sparse-fieldsets&fields=metadata.name,spec.description")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, contents, 1)
+		assert.Equal(t, map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "sparse-fieldsets"},
+			"spec":     map[string]interface{}{"description": "D"},
+		}, contents[0])
+	})
+
+	t.Run("view=full returns everything", func(t *testing.T) {
+		_, contents := get("/quickstarts?name=sparse-fieldsets&view=full")
+		assert.Len(t, contents, 1)
+		assert.Contains(t, contents[0]["spec"], "tasks")
+	})
+
+	t.Run("invalid field is rejected", func(t *testing.T) {
+		w, _ := get("/quickstarts?fields=spec.display%27Name")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("help topics support view=summary", func(t *testing.T) {
+		w, _ := get("/helptopics?view=summary")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/RedHatInsights/quickstarts/pkg/securitylog"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+)
+
+// isInternal reports whether the request comes from a Red Hat associate or an
+// internal user.
+func isInternal(r *http.Request) bool {
+	id, ok := r.Context().Value(identity.Key).(identity.XRHID)
+	if !ok {
+		return false
+	}
+	return id.Identity.Type == "Associate" || id.Identity.User.Internal
+}
+
+// authorizeDrafts reports whether the request may go on and whether it asked
+// for drafts. Drafts are for internal identities only; other requests for
+// them get a 403.
+func authorizeDrafts(w http.ResponseWriter, r *http.Request, requested *bool, resource string) (includeDrafts, ok bool) {
+	if requested == nil || !*requested {
+		return false, true
+	}
+	if !isInternal(r) {
+		securitylog.LogWithReason(r.Context(), "AUTHORIZE", "drafts", resource, "failure", "missing internal identity")
+		utils.ErrorResponse(w, r, utils.ForbiddenError("includeDrafts requires an internal identity"))
+		return false, false
+	}
+	return true, true
+}
@@ -38,7 +38,7 @@ func mockQuickstartFavoritable(qsName string) *models.FavoriteQuickstart {
 func setupFavoriteQuickstartRouter() *chi.Mux {
 	r := chi.NewRouter()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	generated.HandlerFromMux(adapter, r)
 
 	return r
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
 	"github.com/RedHatInsights/quickstarts/pkg/securitylog"
 	"github.com/RedHatInsights/quickstarts/pkg/utils"
 )
@@ -13,24 +14,22 @@ import (
 func (s *ServerAdapter) GetFavorites(w http.ResponseWriter, r *http.Request, params generated.GetFavoritesParams) {
 	// Validate account parameter
 	if params.Account == "" {
-		utils.ErrorResponse(w, http.StatusBadRequest, "missing account parameter")
+		utils.ErrorResponse(w, r, utils.ValidationError("missing account parameter"))
 		return
 	}
 
 	// Use service to get favorites for the account
-	favorites, err := s.favoriteService.GetFavorites(params.Account)
+	timing := utils.NewServerTiming()
+	stopDB := timing.Start("db")
+	favorites, err := s.favoriteService.WithContext(r.Context()).GetFavorites(params.Account)
+	stopDB()
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
 	// Convert to generated types and respond
-	genFavorites := make([]generated.FavoriteQuickstart, len(favorites))
-	for i, favorite := range favorites {
-		genFavorites[i] = favorite.ToAPI()
-	}
-
-	utils.DataResponse(w, http.StatusOK, genFavorites)
+	utils.StreamDataResponse(w, http.StatusOK, timing, favorites, models.FavoriteQuickstart.ToAPI)
 }
 
 // PostFavorites handles POST /favorites
@@ -39,7 +38,7 @@ func (s *ServerAdapter) PostFavorites(w http.ResponseWriter, r *http.Request, pa
 	// (malformed client requests, not data access attempts) so no security log here.
 	var reqBody generated.FavoriteQuickstart
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -53,11 +52,18 @@ func (s *ServerAdapter) PostFavorites(w http.ResponseWriter, r *http.Request, pa
 		favorite = *reqBody.Favorite
 	}
 
+	// Favorites of renamed quickstarts are kept under the current name
+	quickstartName, err := s.quickstartService.WithContext(r.Context()).CurrentName(quickstartName)
+	if err != nil {
+		utils.ErrorResponse(w, r, err)
+		return
+	}
+
 	// Use service to switch favorite status
-	result, err := s.favoriteService.SwitchFavorite(params.Account, quickstartName, favorite)
+	result, err := s.favoriteService.WithContext(r.Context()).SwitchFavorite(params.Account, quickstartName, favorite)
 	if err != nil {
 		securitylog.LogWithReason(r.Context(), "CREATE", "favorite", quickstartName, "failure", err.Error())
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
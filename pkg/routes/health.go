@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/sirupsen/logrus"
+)
+
+// readinessCheckTimeout bounds each dependency check so a hung dependency
+// fails the probe instead of stalling it.
+const readinessCheckTimeout = 2 * time.Second
+
+// HealthCheck is a dependency the service needs to serve traffic. Optional
+// dependencies are reported by the readiness probe without failing it.
+type HealthCheck struct {
+	Name     string
+	Check    func(ctx context.Context) error
+	Optional bool
+}
+
+// Health serves the liveness and readiness probes.
+type Health struct {
+	checks   []HealthCheck
+	draining atomic.Bool
+}
+
+// NewHealth creates probes whose readiness depends on checks.
+func NewHealth(checks ...HealthCheck) *Health {
+	return &Health{checks: checks}
+}
+
+// Drain makes readiness fail from now on, so the pod is taken out of
+// rotation while in-flight requests finish during shutdown.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Livez reports that the process is running. It never checks dependencies,
+// so an outage elsewhere does not restart healthy pods.
+func (h *Health) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Readyz runs every check and responds 503 if a required one fails or the
+// server is draining. The probe is unauthenticated, so a failed check is only
+// reported as unavailable; its error is logged.
+func (h *Health) Readyz(w http.ResponseWriter, r *http.Request) {
+	resp := readinessResponse{Status: "ready", Checks: make(map[string]string, len(h.checks))}
+	status := http.StatusOK
+
+	if h.draining.Load() {
+		resp.Status = "draining"
+		status = http.StatusServiceUnavailable
+	}
+
+	for _, c := range h.checks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		err := c.Check(ctx)
+		cancel()
+		if err != nil {
+			logrus.WithContext(r.Context()).WithError(err).WithField("check", c.Name).Warn("Readiness check failed")
+			resp.Checks[c.Name] = "unavailable"
+			if !c.Optional && status == http.StatusOK {
+				resp.Status = "not ready"
+				status = http.StatusServiceUnavailable
+			}
+			continue
+		}
+		resp.Checks[c.Name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DatabaseCheck verifies the database accepts connections.
+func DatabaseCheck() HealthCheck {
+	return HealthCheck{Name: "database", Check: func(ctx context.Context) error {
+		sqlDB, err := database.DB.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}}
+}
+
+// SeedCheck verifies content has been seeded at least once, so the pod does
+// not serve an empty catalog.
+func SeedCheck() HealthCheck {
+	return HealthCheck{Name: "seed", Check: func(ctx context.Context) error {
+		generation, err := database.CurrentSeedGeneration(database.DB.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if generation == 0 {
+			return errors.New("content has not been seeded")
+		}
+		return nil
+	}}
+}
+
+// HealthChecks returns the readiness checks for the adapter's dependencies.
+// git-service is only checked when the integration is enabled, and is
+// optional: an outage only affects PR submission, not the catalog.
+func (s *ServerAdapter) HealthChecks() []HealthCheck {
+	checks := []HealthCheck{DatabaseCheck(), SeedCheck()}
+	if s.gitServiceEnabled {
+		checks = append(checks, HealthCheck{Name: "git-service", Check: s.gitServiceClient.Ping, Optional: true})
+	}
+	return checks
+}
@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/clients"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readyzResponse(t *testing.T, h *Health) (int, readinessResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var resp readinessResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return w.Code, resp
+}
+
+func TestLivez(t *testing.T) {
+	h := NewHealth(HealthCheck{Name: "broken", Check: func(ctx context.Context) error {
+		return errors.New("down")
+	}})
+	w := httptest.NewRecorder()
+
+	h.Livez(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "OK", w.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	t.Run("not ready before content is seeded", func(t *testing.T) {
+		code, resp := readyzResponse(t, NewHealth(DatabaseCheck(), SeedCheck()))
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "not ready", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["database"])
+		assert.Equal(t, "unavailable", resp.Checks["seed"], "errors are logged, not returned")
+	})
+
+	seed := models.SeedGeneration{ID: models.SeedGenerationID, Generation: 1}
+	require.NoError(t, database.DB.Create(&seed).Error)
+	defer database.DB.Unscoped().Delete(&seed)
+
+	t.Run("ready once seeded", func(t *testing.T) {
+		code, resp := readyzResponse(t, NewHealth(DatabaseCheck(), SeedCheck()))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["seed"])
+	})
+
+	t.Run("not ready while draining", func(t *testing.T) {
+		h := NewHealth(DatabaseCheck(), SeedCheck())
+		h.Drain()
+
+		code, resp := readyzResponse(t, h)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "draining", resp.Status)
+	})
+
+	t.Run("ready when git-service is unreachable", func(t *testing.T) {
+		gitService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer gitService.Close()
+		adapter := &ServerAdapter{
+			gitServiceClient:  clients.NewGitService(gitService.URL, "token"),
+			gitServiceEnabled: true,
+		}
+
+		code, resp := readyzResponse(t, NewHealth(adapter.HealthChecks()...))
+
+		assert.Equal(t, http.StatusOK, code, "git-service is optional")
+		assert.Equal(t, "ready", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["seed"])
+		assert.Equal(t, "unavailable", resp.Checks["git-service"])
+	})
+}
@@ -38,7 +38,7 @@ type helpTopicMessageResponsePayload struct {
 func setupHelpTopicRouter() *chi.Mux {
 	r := chi.NewRouter()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		params := generated.GetHelptopicsParams{}
@@ -70,7 +70,7 @@ func setupHelpTopicRouter() *chi.Mux {
 	r.Route("/{name}", func(sub chi.Router) {
 		sub.Get("/", func(w http.ResponseWriter, r *http.Request) {
 			name := chi.URLParam(r, "name")
-			adapter.GetHelptopicsName(w, r, name)
+			adapter.GetHelptopicsName(w, r, name, generated.GetHelptopicsNameParams{})
 		})
 	})
 	return r
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/services"
 	"github.com/RedHatInsights/quickstarts/pkg/utils"
 )
 
@@ -24,28 +26,54 @@ func (s *ServerAdapter) GetHelptopics(w http.ResponseWriter, r *http.Request, pa
 		nameQueries = utils.ConvertStringSlice(params.Name)
 	}
 
-	// Use service layer for data access
-	helpTopics, err := s.helpTopicService.FindWithFilters(bundleQueries, applicationQueries, nameQueries)
+	var view string
+	if params.View != nil {
+		view = string(*params.View)
+	}
+	projection, err := contentProjection(params.Fields, view, services.HelpTopicSummaryProjection)
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, utils.ValidationError(err.Error()))
 		return
 	}
 
-	// Convert to generated types and respond
-	genHelpTopics := make([]generated.HelpTopic, len(helpTopics))
-	for i, topic := range helpTopics {
-		genHelpTopics[i] = topic.ToAPI()
+	includeDrafts, ok := authorizeDrafts(w, r, params.IncludeDrafts, "helptopics")
+	if !ok {
+		return
+	}
+	helpTopicService := s.helpTopicService.WithContext(r.Context()).WithProjection(projection)
+	if includeDrafts {
+		helpTopicService = helpTopicService.WithDrafts()
 	}
 
-	utils.DataResponse(w, http.StatusOK, genHelpTopics)
+	// Use service layer for data access
+	timing := utils.NewServerTiming()
+	stopDB := timing.Start("db")
+	helpTopics, err := helpTopicService.FindWithFilters(bundleQueries, applicationQueries, nameQueries)
+	stopDB()
+	if err != nil {
+		utils.ErrorResponse(w, r, err)
+		return
+	}
+
+	// Convert to generated types and respond
+	utils.StreamDataResponse(w, http.StatusOK, timing, helpTopics, models.HelpTopic.ToAPI)
 }
 
 // GetHelptopicsName handles GET /helptopics/{name}
-func (s *ServerAdapter) GetHelptopicsName(w http.ResponseWriter, r *http.Request, name string) {
+func (s *ServerAdapter) GetHelptopicsName(w http.ResponseWriter, r *http.Request, name string, params generated.GetHelptopicsNameParams) {
+	includeDrafts, ok := authorizeDrafts(w, r, params.IncludeDrafts, "helptopics")
+	if !ok {
+		return
+	}
+	helpTopicService := s.helpTopicService.WithContext(r.Context())
+	if includeDrafts {
+		helpTopicService = helpTopicService.WithDrafts()
+	}
+
 	// Find the help topic by name using service
-	helpTopic, err := s.helpTopicService.FindByName(name)
+	helpTopic, err := helpTopicService.FindByName(name)
 	if err != nil {
-		utils.NotFoundResponse(w, "Help topic")
+		utils.ErrorResponse(w, r, utils.LookupError("Help topic", err))
 		return
 	}
 
@@ -8,7 +8,7 @@ import (
 
 	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/database"
-	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 )
 
 func TestMain(m *testing.M) {
@@ -20,6 +20,9 @@ func TestMain(m *testing.M) {
 
 var dbName string
 
+// testRepos are the database-backed repositories used by the handler tests.
+var testRepos repository.Repositories
+
 func setUp() {
 	config.Init()
 	cfg := config.Get()
@@ -34,7 +37,7 @@ func setUp() {
 	}
 
 	database.Init()
-	err := database.DB.AutoMigrate(&models.Tag{}, &models.Quickstart{}, &models.QuickstartProgress{}, &models.HelpTopic{}, &models.FavoriteQuickstart{})
+	err := database.MigrateTestSchema(database.DB)
 	if err != nil {
 		panic(err)
 	}
@@ -43,6 +46,7 @@ func setUp() {
 	if err := database.CleanTestTables(); err != nil {
 		panic(fmt.Sprintf("CleanTestTables failed: %s", err.Error()))
 	}
+	testRepos = repository.NewGorm(database.DB, database.IsFuzzySearchSupported())
 }
 
 func tearDown() {
@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/redhatinsights/platform-go-middlewares/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests run the handlers against the in-memory repositories, without a
+// database.
+func newMemoryRouter(t *testing.T) (*repository.Memory, http.Handler) {
+	t.Helper()
+	m := repository.NewMemory()
+	r := chi.NewRouter()
+	generated.HandlerFromMux(NewServerAdapter(m.Repositories()), r)
+	return m, r
+}
+
+func serve(h http.Handler, method, url, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != "" {
+		req = httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, url, nil)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestMemoryQuickstartHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+	rhel := models.Tag{Type: models.BundleTag, Value: "rhel"}
+	insights := models.Tag{Type: models.BundleTag, Value: "insights"}
+	first := m.AddQuickstart(models.Quickstart{Name: "first", Content: []byte(`{"spec":{"displayName":"First"}}`), Tags: []models.Tag{rhel}})
+	m.AddQuickstart(models.Quickstart{Name: "second", Content: []byte(`{"spec":{"displayName":"Second"}}`), Tags: []models.Tag{insights}})
+
+	t.Run("list filtered by bundle", func(t *testing.T) {
+		w := serve(router, http.MethodGet, "/quickstarts?bundle=rhel", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var payload ResponsePayload
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		require.Len(t, payload.Data, 1)
+		assert.Equal(t, "first", payload.Data[0].Name)
+	})
+
+	t.Run("fuzzy search falls back to display name match", func(t *testing.T) {
+		w := serve(router, http.MethodGet, "/quickstarts?display-name=secon&fuzzy=true", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var payload ResponsePayload
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		require.Len(t, payload.Data, 1)
+		assert.Equal(t, "second", payload.Data[0].Name)
+	})
+
+	t.Run("get by id", func(t *testing.T) {
+		w := serve(router, http.MethodGet, fmt.Sprintf("/quickstarts/%d", first.ID), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var payload SingleResponsePayload
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		assert.Equal(t, "first", payload.Data.Name)
+
+		w = serve(router, http.MethodGet, "/quickstarts/999", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestMemoryHelpTopicHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+	m.AddHelpTopic(models.HelpTopic{Name: "topic", GroupName: "group", Content: []byte(`{}`), Tags: []models.Tag{{Type: models.BundleTag, Value: "rhel"}}})
+
+	w := serve(router, http.MethodGet, "/helptopics?bundle=rhel", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var payload ResponsePayload
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+	assert.Len(t, payload.Data, 1)
+
+	w = serve(router, http.MethodGet, "/helptopics/missing", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMemoryFavoriteHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+	m.AddQuickstart(models.Quickstart{Name: "first", Content: []byte(`{}`)})
+
+	w := serve(router, http.MethodPost, "/favorites?account=123", `{"quickstartName":"first","favorite":true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, http.MethodGet, "/favorites?account=123", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"quickstartName":"first"`)
+
+	w = serve(router, http.MethodPost, "/favorites?account=123", `{"quickstartName":"first","favorite":false}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(router, http.MethodGet, "/favorites?account=123", "")
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+
+	w = serve(router, http.MethodPost, "/favorites?account=123", `{"quickstartName":"missing","favorite":true}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMemoryProgressHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+
+	w := serve(router, http.MethodPost, "/progress", `{"accountId":7,"quickstartName":"first","progress":{"step":1}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(router, http.MethodPost, "/progress", `{"accountId":7,"quickstartName":"first","progress":{"step":2}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, http.MethodGet, "/progress?account=7", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listed struct {
+		Data []struct {
+			Progress map[string]int `json:"progress"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listed))
+	require.Len(t, listed.Data, 1, "the second post updates the existing record")
+	assert.Equal(t, 2, listed.Data[0].Progress["step"])
+
+	// Progress responses carry no id, so look it up in the store.
+	stored, err := m.Repositories().Progress.FindOne(context.Background(), 7, "first")
+	require.NoError(t, err)
+	w = serve(router, http.MethodDelete, fmt.Sprintf("/progress/%d", stored.ID), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(router, http.MethodDelete, fmt.Sprintf("/progress/%d", stored.ID), "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMemoryRenamedQuickstartHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+	m.AddQuickstart(models.Quickstart{Name: "current", Content: []byte(`{"spec":{"displayName":"Current"}}`), PreviousNames: []string{"previous"}})
+
+	w := serve(router, http.MethodGet, "/quickstarts?name=previous", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var payload struct {
+		Data []generated.Quickstart `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+	require.Len(t, payload.Data, 1)
+	assert.Equal(t, "current", *payload.Data[0].Name)
+	require.NotNil(t, payload.Data[0].RedirectedFrom)
+	assert.Equal(t, "previous", *payload.Data[0].RedirectedFrom)
+
+	w = serve(router, http.MethodGet, "/quickstarts?name=current", "")
+	assert.NotContains(t, w.Body.String(), "redirectedFrom")
+
+	w = serve(router, http.MethodPost, "/favorites?account=123", `{"quickstartName":"previous","favorite":true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"quickstartName":"current"`, "favorites are stored under the current name")
+
+	w = serve(router, http.MethodPost, "/progress", `{"accountId":7,"quickstartName":"previous","progress":{"step":1}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(router, http.MethodGet, "/progress?account=7&quickstart=previous", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"quickstartName":"current"`)
+}
+
+func TestMemoryLifecycleHandlers(t *testing.T) {
+	m, router := newMemoryRouter(t)
+	m.AddQuickstart(models.Quickstart{Name: "published", Content: []byte(`{}`)})
+	m.AddQuickstart(models.Quickstart{Name: "old", Content: []byte(`{}`), Lifecycle: models.Lifecycle{Status: models.StatusDeprecated, Replacement: "published"}})
+	draft := m.AddQuickstart(models.Quickstart{Name: "draft", Content: []byte(`{}`), Lifecycle: models.Lifecycle{Status: models.StatusDraft}})
+	m.AddHelpTopic(models.HelpTopic{Name: "draft-topic", GroupName: "group", Content: []byte(`{}`), Lifecycle: models.Lifecycle{Status: models.StatusDraft}})
+
+	internal := func(method, url string) *httptest.ResponseRecorder {
+		id := identity.XRHID{Identity: identity.Identity{Type: "Associate"}}
+		req := httptest.NewRequest(method, url, nil)
+		req = req.WithContext(context.WithValue(req.Context(), identity.Key, id))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("drafts are hidden", func(t *testing.T) {
+		w := serve(router, http.MethodGet, "/quickstarts", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var payload struct {
+			Data []generated.Quickstart `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		require.Len(t, payload.Data, 2)
+
+		assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, fmt.Sprintf("/quickstarts/%d", draft.ID), "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/helptopics/draft-topic", "").Code)
+	})
+
+	t.Run("deprecated quickstarts are flagged", func(t *testing.T) {
+		w := serve(router, http.MethodGet, "/quickstarts?name=old", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var payload struct {
+			Data []generated.Quickstart `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		require.Len(t, payload.Data, 1)
+		require.NotNil(t, payload.Data[0].Deprecated)
+		assert.True(t, *payload.Data[0].Deprecated)
+		require.NotNil(t, payload.Data[0].Lifecycle)
+		assert.Equal(t, "published", *payload.Data[0].Lifecycle.Replacement)
+
+		w = serve(router, http.MethodGet, "/quickstarts?name=published", "")
+		assert.NotContains(t, w.Body.String(), "deprecated")
+	})
+
+	t.Run("drafts need an internal identity", func(t *testing.T) {
+		for _, url := range []string{"/quickstarts?includeDrafts=true", fmt.Sprintf("/quickstarts/%d?includeDrafts=true", draft.ID), "/helptopics?includeDrafts=true", "/helptopics/draft-topic?includeDrafts=true"} {
+			assert.Equal(t, http.StatusForbidden, serve(router, http.MethodGet, url, "").Code, url)
+			assert.Equal(t, http.StatusOK, internal(http.MethodGet, url).Code, url)
+		}
+
+		w := internal(http.MethodGet, "/quickstarts?includeDrafts=true")
+		var payload struct {
+			Data []generated.Quickstart `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+		assert.Len(t, payload.Data, 3)
+	})
+}
@@ -3,16 +3,31 @@ package routes
 import (
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	p "github.com/prometheus/client_golang/prometheus"
 	pa "github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// unmatchedRoute labels requests that did not match any route, so scanners
+// cannot inflate label cardinality with arbitrary paths.
+const unmatchedRoute = "unmatched"
+
 var (
 	apiResponseCodes = pa.NewCounterVec(p.CounterOpts{
 		Name: "quickstarts_responses",
 		Help: "Total number of HTTP requests against quickstarts API",
 	}, []string{"code"})
+	apiRequestDuration = pa.NewHistogramVec(p.HistogramOpts{
+		Name:    "quickstarts_http_request_duration_seconds",
+		Help:    "Duration of HTTP requests against quickstarts API by route pattern",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "code"})
+	apiRequestsInFlight = pa.NewGauge(p.GaugeOpts{
+		Name: "quickstarts_http_requests_in_flight",
+		Help: "Number of HTTP requests against quickstarts API currently being served",
+	})
 )
 
 type statusRecorder struct {
@@ -26,10 +41,26 @@ func (rec *statusRecorder) WriteHeader(statusCode int) {
 	rec.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
 func PrometheusMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiRequestsInFlight.Inc()
+		defer apiRequestsInFlight.Dec()
+		start := time.Now()
+
 		/**Initialize with 200 if the WriteHeader was not called*/
 		rec := statusRecorder{w, 200}
 		next.ServeHTTP(&rec, r)
+
+		// The pattern is only complete once chi has routed the request.
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		apiRequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(rec.status)).Observe(time.Since(start).Seconds())
 	})
 }
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusMiddlewareLabelsRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.With(PrometheusMiddleware).Get("/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics-test/"+id, nil))
+	}
+
+	w := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	// One series per route pattern, not per concrete path.
+	assert.Contains(t, w.Body.String(), `quickstarts_http_request_duration_seconds_count{code="418",method="GET",route="/metrics-test/{id}"} 3`)
+	assert.NotContains(t, w.Body.String(), `route="/metrics-test/1"`)
+	assert.Contains(t, w.Body.String(), "quickstarts_http_requests_in_flight 0")
+}
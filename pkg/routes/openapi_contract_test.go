@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	qsmiddleware "github.com/RedHatInsights/quickstarts/pkg/middleware"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPIContract runs the real handlers behind the validator in strict
+// mode, so any response that drifts from spec/openapi.yaml fails with a 500.
+func TestOpenAPIContract(t *testing.T) {
+	q := models.Quickstart{
+		Name:    "contract-quickstart",
+		Content: []byte(`{"metadata":{"name":"contract-quickstart"},"spec":{"displayName":"Contract","description":"D","type":{"text":"Quick start"}}}`),
+	}
+	database.DB.Create(&q)
+	// Other tests in this package count every quickstart in the table.
+	defer database.DB.Unscoped().Delete(&q)
+	h := models.HelpTopic{
+		Name:      "contract-topic",
+		GroupName: "contract-group",
+		Content:   []byte(`{"name":"contract-topic","title":"Contract"}`),
+	}
+	database.DB.Create(&h)
+	defer database.DB.Unscoped().Delete(&h)
+	defer database.DB.Unscoped().Where("account_id = ?", "contract").Delete(&models.FavoriteQuickstart{})
+	defer database.DB.Unscoped().Where("account_id = ?", 4242).Delete(&models.QuickstartProgress{})
+
+	validator, err := qsmiddleware.OpenAPIValidator("../../spec/openapi.json", qsmiddleware.OpenAPIValidatorOptions{Strict: true})
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Use(validator)
+	generated.HandlerWithOptions(NewServerAdapter(testRepos), generated.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: ParamErrorHandler,
+	})
+
+	tests := []struct {
+		method     string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{http.MethodGet, "/quickstarts", "", http.StatusOK},
+		{http.MethodGet, "/quickstarts?view=summary&limit=5", "", http.StatusOK},
+		{http.MethodGet, "/quickstarts?fields=metadata.name", "", http.StatusOK},
+		{http.MethodGet, fmt.Sprintf("/quickstarts/%d", q.ID), "", http.StatusOK},
+		{http.MethodGet, "/quickstarts/987654", "", http.StatusNotFound},
+		{http.MethodGet, "/quickstarts/filters", "", http.StatusOK},
+		{http.MethodGet, "/helptopics", "", http.StatusOK},
+		{http.MethodGet, "/helptopics/contract-topic", "", http.StatusOK},
+		{http.MethodGet, "/helptopics/missing-topic", "", http.StatusNotFound},
+		{http.MethodPost, "/favorites?account=contract", `{"quickstartName":"contract-quickstart","favorite":true}`, http.StatusOK},
+		{http.MethodGet, "/favorites?account=contract", "", http.StatusOK},
+		{http.MethodPost, "/progress", `{"accountId":4242,"quickstartName":"contract-quickstart","progress":{"step":1}}`, http.StatusOK},
+		{http.MethodGet, "/progress?account=4242", "", http.StatusOK},
+		{http.MethodDelete, "/progress/987654", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
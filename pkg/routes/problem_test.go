@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/clients"
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/redhatinsights/platform-go-middlewares/request_id"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupProblemRouter(adapter *ServerAdapter) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(request_id.ConfiguredRequestID(utils.RequestIDHeader))
+	generated.HandlerWithOptions(adapter, generated.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: ParamErrorHandler,
+	})
+	return r
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) ProblemResponsePayload {
+	t.Helper()
+	assert.Equal(t, utils.ProblemContentType, w.Header().Get("Content-Type"))
+	var payload ProblemResponsePayload
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&payload))
+	return payload
+}
+
+func TestProblemResponses(t *testing.T) {
+	t.Run("record not found maps to 404 with the request id as instance", func(t *testing.T) {
+		router := setupProblemRouter(NewServerAdapter(testRepos))
+		req := httptest.NewRequest(http.MethodGet, "/quickstarts/987654", nil)
+		req.Header.Set(utils.RequestIDHeader, "req-123")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		payload := decodeProblem(t, w)
+		assert.Equal(t, http.StatusNotFound, payload.Status)
+		assert.Equal(t, "urn:quickstarts:problem:not-found", payload.Type)
+		assert.Equal(t, "Quickstart not found", payload.Detail)
+		assert.Equal(t, "req-123", payload.Instance)
+	})
+
+	t.Run("parameter binding errors are validation problems", func(t *testing.T) {
+		router := setupProblemRouter(NewServerAdapter(testRepos))
+		req := httptest.NewRequest(http.MethodGet, "/quickstarts/not-a-number", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		payload := decodeProblem(t, w)
+		assert.Equal(t, "urn:quickstarts:problem:validation", payload.Type)
+		assert.NotEmpty(t, payload.Instance)
+	})
+
+	t.Run("upstream not found is passed through", func(t *testing.T) {
+		mockGitService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "req-456", r.Header.Get(utils.RequestIDHeader))
+			w.Header().Set("Content-Type", utils.ProblemContentType)
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"type":   "urn:quickstarts-git-service:problem:not-found",
+				"title":  "Not Found",
+				"status": http.StatusNotFound,
+				"detail": "quickstart not found",
+			})
+		}))
+		defer mockGitService.Close()
+
+		adapter := NewServerAdapter(testRepos)
+		adapter.gitServiceClient = clients.NewGitService(mockGitService.URL, "")
+		adapter.gitServiceEnabled = true
+		router := setupProblemRouter(adapter)
+		req := httptest.NewRequest(http.MethodGet, "/repo-quickstarts/missing", nil)
+		req.Header.Set(utils.RequestIDHeader, "req-456")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		payload := decodeProblem(t, w)
+		assert.Equal(t, "quickstart not found", payload.Detail)
+		assert.Equal(t, "req-456", payload.Instance)
+	})
+
+	t.Run("upstream server errors become 502 without internal details", func(t *testing.T) {
+		mockGitService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", utils.ProblemContentType)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": http.StatusInternalServerError,
+				"detail": "failed to pull latest changes",
+			})
+		}))
+		defer mockGitService.Close()
+
+		adapter := NewServerAdapter(testRepos)
+		adapter.gitServiceClient = clients.NewGitService(mockGitService.URL, "")
+		adapter.gitServiceEnabled = true
+		router := setupProblemRouter(adapter)
+		req := httptest.NewRequest(http.MethodGet, "/repo-quickstarts", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+		payload := decodeProblem(t, w)
+		assert.Equal(t, "urn:quickstarts:problem:upstream", payload.Type)
+		assert.Equal(t, "git-service request failed", payload.Detail)
+	})
+}
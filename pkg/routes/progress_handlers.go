@@ -22,33 +22,41 @@ func (s *ServerAdapter) GetProgress(w http.ResponseWriter, r *http.Request, para
 	var accountId *int
 	if params.Account != "" {
 		if accountVal, parseErr := strconv.Atoi(params.Account); parseErr != nil {
-			utils.ErrorResponse(w, http.StatusBadRequest, "Invalid account ID: must be an integer")
+			utils.ErrorResponse(w, r, utils.ValidationError("Invalid account ID: must be an integer"))
 			return
 		} else {
 			accountId = &accountVal
 		}
 	}
 
+	// Progress of renamed quickstarts is kept under the current name
+	if params.Quickstart != nil {
+		current, err := s.quickstartService.WithContext(r.Context()).CurrentName(*params.Quickstart)
+		if err != nil {
+			utils.ErrorResponse(w, r, err)
+			return
+		}
+		params.Quickstart = &current
+	}
+
 	// If both account and quickstart filters are provided, or if neither are provided,
 	// use the filtered search. If only one is provided, use it as a filter.
+	timing := utils.NewServerTiming()
+	stopDB := timing.Start("db")
 	if accountId != nil || params.Quickstart != nil {
-		progresses, err = s.progressService.GetProgress(accountId, params.Quickstart)
+		progresses, err = s.progressService.WithContext(r.Context()).GetProgress(accountId, params.Quickstart)
 	} else {
-		progresses, err = s.progressService.GetAllProgress()
+		progresses, err = s.progressService.WithContext(r.Context()).GetAllProgress()
 	}
+	stopDB()
 
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
 	// Convert to generated types and respond
-	genProgresses := make([]generated.QuickstartProgress, len(progresses))
-	for i, progress := range progresses {
-		genProgresses[i] = progress.ToAPI()
-	}
-
-	utils.DataResponse(w, http.StatusOK, genProgresses)
+	utils.StreamDataResponse(w, http.StatusOK, timing, progresses, models.QuickstartProgress.ToAPI)
 }
 
 // PostProgress handles POST /progress
@@ -57,37 +65,44 @@ func (s *ServerAdapter) PostProgress(w http.ResponseWriter, r *http.Request) {
 	// (malformed client requests, not data access attempts) so no security log here.
 	var reqBody generated.QuickstartProgressRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
 	// Validate required fields
 	if reqBody.AccountId == 0 || reqBody.QuickstartName == "" {
-		utils.ErrorResponse(w, http.StatusBadRequest, "Bad request! Missing accountId or quickstartName.")
+		utils.ErrorResponse(w, r, utils.ValidationError("Bad request! Missing accountId or quickstartName."))
+		return
+	}
+
+	quickstartName, err := s.quickstartService.WithContext(r.Context()).CurrentName(reqBody.QuickstartName)
+	if err != nil {
+		utils.ErrorResponse(w, r, err)
 		return
 	}
+	reqBody.QuickstartName = quickstartName
 
 	// Convert progress data to JSONB format
 	var progressData *datatypes.JSON
 	if reqBody.Progress != nil {
 		progressJSON, err := json.Marshal(*reqBody.Progress)
 		if err != nil {
-			utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			utils.ErrorResponse(w, r, utils.ValidationError("invalid progress data"))
 			return
 		}
 		progressData = &datatypes.JSON{}
 		if err := progressData.UnmarshalJSON(progressJSON); err != nil {
-			utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			utils.ErrorResponse(w, r, utils.ValidationError("invalid progress data"))
 			return
 		}
 	}
 
 	// Use service to update progress
 	resourceID := fmt.Sprintf("%d/%s", reqBody.AccountId, reqBody.QuickstartName)
-	progress, err := s.progressService.UpdateProgress(reqBody.AccountId, reqBody.QuickstartName, progressData)
+	progress, err := s.progressService.WithContext(r.Context()).UpdateProgress(reqBody.AccountId, reqBody.QuickstartName, progressData)
 	if err != nil {
 		securitylog.LogWithReason(r.Context(), "UPDATE", "progress", resourceID, "failure", err.Error())
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
@@ -103,10 +118,10 @@ func (s *ServerAdapter) DeleteProgressId(w http.ResponseWriter, r *http.Request,
 	resourceID := strconv.Itoa(id)
 
 	// Use service to delete progress
-	err := s.progressService.DeleteProgress(id)
+	err := s.progressService.WithContext(r.Context()).DeleteProgress(id)
 	if err != nil {
 		securitylog.LogWithReason(r.Context(), "DELETE", "progress", resourceID, "failure", err.Error())
-		utils.NotFoundResponse(w, "Progress record")
+		utils.ErrorResponse(w, r, utils.LookupError("Progress record", err))
 		return
 	}
 
@@ -34,7 +34,7 @@ func mockQuickstartProgress(name string) *models.QuickstartProgress {
 func setupQuickstartProgressRouter() *chi.Mux {
 	r := chi.NewRouter()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		params := generated.GetProgressParams{}
@@ -142,11 +142,13 @@ func TestUpdateQuickstartsProgress(t *testing.T) {
 
 		router.ServeHTTP(response, request)
 
-		var payload *MessageResponsePayload
+		var payload *ProblemResponsePayload
 
 		json.NewDecoder(response.Body).Decode(&payload)
 		assert.Equal(t, 400, response.Code)
-		assert.Equal(t, "Bad request! Missing accountId or quickstartName.", payload.Msg)
+		assert.Equal(t, "application/problem+json", response.Header().Get("Content-Type"))
+		assert.Equal(t, 400, payload.Status)
+		assert.Equal(t, "Bad request! Missing accountId or quickstartName.", payload.Detail)
 	})
 
 	t.Run("should create new entity", func(t *testing.T) {
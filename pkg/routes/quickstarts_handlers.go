@@ -6,6 +6,7 @@ import (
 
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
 	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/services"
 	"github.com/RedHatInsights/quickstarts/pkg/utils"
 )
 
@@ -13,48 +14,67 @@ import (
 func (s *ServerAdapter) GetQuickstarts(w http.ResponseWriter, r *http.Request, params generated.GetQuickstartsParams) {
 	q := NewQuickstartsQuery(r, params)
 
+	var view string
+	if params.View != nil {
+		view = string(*params.View)
+	}
+	projection, err := contentProjection(params.Fields, view, services.QuickstartSummaryProjection)
+	if err != nil {
+		utils.ErrorResponse(w, r, utils.ValidationError(err.Error()))
+		return
+	}
+	includeDrafts, ok := authorizeDrafts(w, r, params.IncludeDrafts, "quickstarts")
+	if !ok {
+		return
+	}
+	quickstartService := s.quickstartService.WithContext(r.Context()).WithProjection(projection)
+	if includeDrafts {
+		quickstartService = quickstartService.WithDrafts()
+	}
+
 	var items []models.Quickstart
-	var err error
+	timing := utils.NewServerTiming()
+	stopDB := timing.Start("db")
 
 	// Use fuzzy search if enabled, otherwise use regular search
 	if q.UseFuzzySearch {
-		items, err = s.quickstartService.FindFuzzy(
+		items, err = quickstartService.FindFuzzy(
 			q.TagTypes, q.TagValues,
 			q.Name, q.DisplayName,
 			q.Limit, q.Offset,
 		)
 	} else {
-		items, err = s.quickstartService.Find(
+		items, err = quickstartService.Find(
 			q.TagTypes, q.TagValues,
 			q.Name, q.DisplayName,
 			q.Limit, q.Offset,
 		)
 	}
+	stopDB()
 
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
-	resp := make([]generated.Quickstart, len(items))
-	for i, it := range items {
-		resp[i] = it.ToAPI()
-	}
-	utils.DataResponse(w, http.StatusOK, resp)
+	utils.StreamDataResponse(w, http.StatusOK, timing, items, models.Quickstart.ToAPI)
 }
 
 // GetQuickstartsId handles GET /quickstarts/{id}
-func (s *ServerAdapter) GetQuickstartsId(w http.ResponseWriter, r *http.Request, id int) {
+func (s *ServerAdapter) GetQuickstartsId(w http.ResponseWriter, r *http.Request, id int, params generated.GetQuickstartsIdParams) {
+	includeDrafts, ok := authorizeDrafts(w, r, params.IncludeDrafts, "quickstarts")
+	if !ok {
+		return
+	}
+	quickstartService := s.quickstartService.WithContext(r.Context())
+	if includeDrafts {
+		quickstartService = quickstartService.WithDrafts()
+	}
+
 	// Find the quickstart by ID using service
-	quickstart, err := s.quickstartService.FindById(id)
+	quickstart, err := quickstartService.FindById(id)
 	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		w.Header().Set("Content-Type", "application/json")
-		msg := err.Error()
-		resp := generated.BadRequest{
-			Msg: &msg,
-		}
-		json.NewEncoder(w).Encode(resp)
+		utils.ErrorResponse(w, r, utils.LookupError("Quickstart", err))
 		return
 	}
 
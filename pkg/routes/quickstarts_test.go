@@ -45,7 +45,7 @@ func mockQuickstart(name string) *models.Quickstart {
 func setupRouter() *chi.Mux {
 	r := chi.NewRouter()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		params := generated.GetQuickstartsParams{}
@@ -116,7 +116,7 @@ func setupRouter() *chi.Mux {
 				w.WriteHeader(http.StatusBadRequest)
 				return
 			}
-			adapter.GetQuickstartsId(w, r, id)
+			adapter.GetQuickstartsId(w, r, id, generated.GetQuickstartsIdParams{})
 		})
 	})
 	return r
@@ -5,19 +5,17 @@ import (
 
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
 	"github.com/RedHatInsights/quickstarts/pkg/utils"
-	"github.com/sirupsen/logrus"
 )
 
 func (s *ServerAdapter) GetRepoQuickstarts(w http.ResponseWriter, r *http.Request) {
 	if !s.gitServiceEnabled {
-		utils.ErrorResponse(w, http.StatusNotFound, "git-service is not available")
+		utils.ErrorResponse(w, r, utils.NotFoundError("git-service is not available"))
 		return
 	}
 
 	result, err := s.gitServiceClient.ListQuickstarts(r.Context())
 	if err != nil {
-		logrus.WithError(err).Error("git-service request failed")
-		utils.ErrorResponse(w, http.StatusBadGateway, "git-service request failed")
+		utils.ErrorResponse(w, r, utils.UpstreamError("git-service", err))
 		return
 	}
 
@@ -38,14 +36,13 @@ func (s *ServerAdapter) GetRepoQuickstarts(w http.ResponseWriter, r *http.Reques
 
 func (s *ServerAdapter) GetRepoQuickstartsName(w http.ResponseWriter, r *http.Request, name string) {
 	if !s.gitServiceEnabled {
-		utils.ErrorResponse(w, http.StatusNotFound, "git-service is not available")
+		utils.ErrorResponse(w, r, utils.NotFoundError("git-service is not available"))
 		return
 	}
 
 	result, err := s.gitServiceClient.GetQuickstartContent(r.Context(), name)
 	if err != nil {
-		logrus.WithError(err).Error("git-service request failed")
-		utils.ErrorResponse(w, http.StatusBadGateway, "git-service request failed")
+		utils.ErrorResponse(w, r, utils.UpstreamError("git-service", err))
 		return
 	}
 
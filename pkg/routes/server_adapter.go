@@ -1,11 +1,14 @@
 package routes
 
 import (
+	"net/http"
 	"os"
 
 	"github.com/RedHatInsights/quickstarts/config"
 	"github.com/RedHatInsights/quickstarts/pkg/clients"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 	"github.com/RedHatInsights/quickstarts/pkg/services"
+	"github.com/RedHatInsights/quickstarts/pkg/utils"
 )
 
 // Pagination holds pagination parameters
@@ -20,12 +23,16 @@ type ServerAdapter struct {
 	helpTopicService  *services.HelpTopicService
 	favoriteService   *services.FavoriteService
 	progressService   *services.ProgressService
+	archiveService    *services.ArchiveService
+	seedRunService    *services.SeedRunService
 	gitServiceClient  *clients.GitService
 	gitServiceEnabled bool
+	adminRoles        []string
 }
 
-// NewServerAdapter creates a new server adapter with service dependencies
-func NewServerAdapter() *ServerAdapter {
+// NewServerAdapter creates a new server adapter whose services read and write
+// through repos
+func NewServerAdapter(repos repository.Repositories) *ServerAdapter {
 	cfg := config.Get()
 	gitEnabled := os.Getenv("GIT_SERVICE_ENABLED") == "true"
 	var gitClient *clients.GitService
@@ -33,11 +40,20 @@ func NewServerAdapter() *ServerAdapter {
 		gitClient = clients.NewGitService(cfg.GitServiceURL, cfg.PSKToken)
 	}
 	return &ServerAdapter{
-		quickstartService: services.NewQuickstartService(),
-		helpTopicService:  services.NewHelpTopicService(),
-		favoriteService:   services.NewFavoriteService(),
-		progressService:   services.NewProgressService(),
+		quickstartService: services.NewQuickstartService(repos.Quickstarts),
+		helpTopicService:  services.NewHelpTopicService(repos.HelpTopics),
+		favoriteService:   services.NewFavoriteService(repos.Favorites),
+		progressService:   services.NewProgressService(repos.Progress),
+		archiveService:    services.NewArchiveService(repos.Archive),
+		seedRunService:    services.NewSeedRunService(repos.SeedRuns),
 		gitServiceClient:  gitClient,
 		gitServiceEnabled: gitEnabled,
+		adminRoles:        cfg.AdminRoles,
 	}
-}
\ No newline at end of file
+}
+
+// ParamErrorHandler reports parameter binding failures from the generated
+// router as validation problems.
+func ParamErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	utils.ErrorResponse(w, r, utils.ValidationError(err.Error()))
+}
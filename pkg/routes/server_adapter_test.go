@@ -18,12 +18,12 @@ func TestServerAdapter_ImplementsInterface(t *testing.T) {
 }
 
 func TestNewServerAdapter(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	assert.NotNil(t, adapter)
 }
 
 func TestServerAdapter_GetQuickstartsFilters(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	req := httptest.NewRequest("GET", "/quickstarts/filters", nil)
 	w := httptest.NewRecorder()
@@ -36,7 +36,7 @@ func TestServerAdapter_GetQuickstartsFilters(t *testing.T) {
 }
 
 func TestServerAdapter_GetQuickstarts_WithParameters(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	// Test with various parameters
 	testCases := []struct {
@@ -79,7 +79,7 @@ func TestServerAdapter_GetQuickstarts_WithParameters(t *testing.T) {
 }
 
 func TestServerAdapter_GetQuickstarts_ErrorConditions(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	testCases := []struct {
 		name           string
@@ -132,7 +132,7 @@ func TestServerAdapter_GetQuickstarts_ErrorConditions(t *testing.T) {
 }
 
 func TestServerAdapter_GetFavorites_RequiresAccount(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	req := httptest.NewRequest("GET", "/favorites", nil)
 	w := httptest.NewRecorder()
@@ -148,7 +148,7 @@ func TestServerAdapter_GetFavorites_RequiresAccount(t *testing.T) {
 }
 
 func TestServerAdapter_GetFavorites_AccountParameterValidation(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	tests := []struct {
 		name           string
@@ -191,7 +191,7 @@ func TestServerAdapter_GetFavorites_AccountParameterValidation(t *testing.T) {
 }
 
 func TestServerAdapter_ProgressEndpoints_Functional(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	// Test GET /progress - should work now
 	req := httptest.NewRequest("GET", "/progress", nil)
@@ -217,7 +217,7 @@ func TestServerAdapter_ProgressEndpoints_Functional(t *testing.T) {
 }
 
 func TestServerAdapter_ProgressEndpoints_Comprehensive(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 
 	// Helper function to create JSON request body
 	createJSONBody := func(jsonStr string) io.Reader {
@@ -7,13 +7,12 @@ import (
 	"github.com/RedHatInsights/quickstarts/pkg/clients"
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
 	"github.com/RedHatInsights/quickstarts/pkg/utils"
-	"github.com/sirupsen/logrus"
 )
 
 // PostPullRequest handles POST /pull-request
 func (s *ServerAdapter) PostPullRequest(w http.ResponseWriter, r *http.Request) {
 	if !s.gitServiceEnabled {
-		utils.ErrorResponse(w, http.StatusNotFound, "git-service is not available")
+		utils.ErrorResponse(w, r, utils.NotFoundError("git-service is not available"))
 		return
 	}
 
@@ -22,20 +21,16 @@ func (s *ServerAdapter) PostPullRequest(w http.ResponseWriter, r *http.Request)
 
 	var reqBody generated.SubmitPrRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		if err.Error() == "http: request body too large" {
-			utils.ErrorResponse(w, http.StatusRequestEntityTooLarge, "request body too large")
-			return
-		}
-		utils.ErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		utils.ErrorResponse(w, r, err)
 		return
 	}
 
 	if len(reqBody.Files) == 0 {
-		utils.ErrorResponse(w, http.StatusBadRequest, "files are required")
+		utils.ErrorResponse(w, r, utils.ValidationError("files are required"))
 		return
 	}
 	if reqBody.Metadata.BranchName == "" || reqBody.Metadata.CommitMessage == "" || reqBody.Metadata.PrTitle == "" {
-		utils.ErrorResponse(w, http.StatusBadRequest, "branchName, commitMessage, and prTitle are required")
+		utils.ErrorResponse(w, r, utils.ValidationError("branchName, commitMessage, and prTitle are required"))
 		return
 	}
 
@@ -60,8 +55,7 @@ func (s *ServerAdapter) PostPullRequest(w http.ResponseWriter, r *http.Request)
 
 	result, err := s.gitServiceClient.SubmitPR(r.Context(), files, metadata)
 	if err != nil {
-		logrus.WithError(err).Error("git-service request failed")
-		utils.ErrorResponse(w, http.StatusBadGateway, "git-service request failed")
+		utils.ErrorResponse(w, r, utils.UpstreamError("git-service", err))
 		return
 	}
 
@@ -32,7 +32,7 @@ func TestPostPullRequest_Success(t *testing.T) {
 	}))
 	defer mockGitService.Close()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceClient = clients.NewGitService(mockGitService.URL, "")
 	adapter.gitServiceEnabled = true
 
@@ -64,7 +64,7 @@ func TestPostPullRequest_Success(t *testing.T) {
 }
 
 func TestPostPullRequest_InvalidJSON(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceEnabled = true
 
 	req := httptest.NewRequest("POST", "/pull-request", strings.NewReader("not json"))
@@ -78,7 +78,7 @@ func TestPostPullRequest_InvalidJSON(t *testing.T) {
 }
 
 func TestPostPullRequest_EmptyFiles(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceEnabled = true
 
 	body := `{
@@ -101,7 +101,7 @@ func TestPostPullRequest_EmptyFiles(t *testing.T) {
 }
 
 func TestPostPullRequest_MissingMetadataFields(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceEnabled = true
 
 	body := `{
@@ -132,7 +132,7 @@ func TestPostPullRequest_GitServiceError(t *testing.T) {
 	}))
 	defer mockGitService.Close()
 
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceClient = clients.NewGitService(mockGitService.URL, "")
 	adapter.gitServiceEnabled = true
 
@@ -157,7 +157,7 @@ func TestPostPullRequest_GitServiceError(t *testing.T) {
 }
 
 func TestPostPullRequest_GitServiceUnreachable(t *testing.T) {
-	adapter := NewServerAdapter()
+	adapter := NewServerAdapter(testRepos)
 	adapter.gitServiceClient = clients.NewGitService("http://localhost:1", "")
 	adapter.gitServiceEnabled = true
 
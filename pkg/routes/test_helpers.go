@@ -17,3 +17,11 @@ type SingleResponsePayload struct {
 type MessageResponsePayload struct {
 	Msg string `json:"msg"`
 }
+
+type ProblemResponsePayload struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+}
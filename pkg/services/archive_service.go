@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/RedHatInsights/quickstarts/pkg/archive"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ArchiveService exports and imports content archives
+type ArchiveService struct {
+	ctx   context.Context
+	repo  repository.ArchiveRepository
+	cache *ContentCache
+}
+
+// NewArchiveService creates a new archive service. repo may be nil when the
+// backend does not support archives.
+func NewArchiveService(repo repository.ArchiveRepository) *ArchiveService {
+	return &ArchiveService{repo: repo, cache: contentCache}
+}
+
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *ArchiveService) WithContext(ctx context.Context) *ArchiveService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
+
+// Enabled reports whether archives are supported.
+func (s *ArchiveService) Enabled() bool {
+	return s.repo != nil
+}
+
+// Export writes an archive of the current content to w
+func (s *ArchiveService) Export(w io.Writer, opts archive.Options) (archive.Counts, error) {
+	return s.repo.Export(s.ctx, w, opts)
+}
+
+// Import loads the archive read from r. The content cache of this process is
+// refreshed right away; other replicas pick the change up on their next poll.
+func (s *ArchiveService) Import(r io.Reader, opts archive.Options) (archive.Counts, error) {
+	counts, err := s.repo.Import(s.ctx, r, opts)
+	if err != nil {
+		return counts, err
+	}
+	if s.cache != nil {
+		if err := s.cache.Refresh(); err != nil {
+			logrus.WithContext(s.ctx).WithError(err).Warn("Failed to refresh content cache after import")
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	p "github.com/prometheus/client_golang/prometheus"
+	pa "github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	contentCacheHits = pa.NewCounterVec(p.CounterOpts{
+		Name: "quickstarts_content_cache_hits_total",
+		Help: "Total number of catalog queries served from the in-process content cache",
+	}, []string{"query"})
+	contentCacheMisses = pa.NewCounterVec(p.CounterOpts{
+		Name: "quickstarts_content_cache_misses_total",
+		Help: "Total number of catalog queries that bypassed the content cache and hit the database",
+	}, []string{"query"})
+	contentCacheGeneration = pa.NewGauge(p.GaugeOpts{
+		Name: "quickstarts_content_cache_generation",
+		Help: "Seed generation currently loaded in the content cache",
+	})
+)
+
+// contentCache is the process-wide cache used by services created after
+// InitContentCache. It stays nil when caching is disabled.
+var contentCache *ContentCache
+
+// InitContentCache loads the catalog from db into memory and keeps it in sync
+// with the seed generation marker until ctx is cancelled. Services created
+// after this call read through the cache.
+func InitContentCache(ctx context.Context, db *gorm.DB, interval time.Duration) error {
+	cache := NewContentCache(db)
+	if err := cache.Refresh(); err != nil {
+		return err
+	}
+	go cache.Run(ctx, interval)
+	contentCache = cache
+	return nil
+}
+
+// cachedQuickstart is a quickstart plus the values filters match against.
+type cachedQuickstart struct {
+	quickstart  models.Quickstart
+	displayName string
+	tags        map[models.TagType]map[string]bool
+}
+
+// cachedHelpTopic is a help topic plus the values filters match against.
+type cachedHelpTopic struct {
+	helpTopic models.HelpTopic
+	tags      map[models.TagType]map[string]bool
+}
+
+// contentSnapshot is an immutable view of the catalog at one seed generation.
+type contentSnapshot struct {
+	generation        int64
+	quickstarts       []cachedQuickstart
+	quickstartsByID   map[uint]int
+	quickstartsByName map[string]int
+	aliases           map[string]string // Previous quickstart names to current ones
+	helpTopics        []cachedHelpTopic
+	helpTopicsByName  map[string]int
+}
+
+// ContentCache holds the quickstart and help topic catalog in memory and
+// answers the filter queries the API issues most often. Data is reloaded
+// whenever the seed generation marker in the database changes, so every
+// replica converges on the same content shortly after a seed.
+type ContentCache struct {
+	db       *gorm.DB
+	mu       sync.RWMutex
+	snapshot *contentSnapshot
+}
+
+// NewContentCache creates an empty cache backed by db. Call Refresh before use.
+func NewContentCache(db *gorm.DB) *ContentCache {
+	return &ContentCache{db: db}
+}
+
+// Run polls the seed generation marker every interval until ctx is done.
+func (c *ContentCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(); err != nil {
+				logrus.WithError(err).Warn("Failed to refresh content cache")
+			}
+		}
+	}
+}
+
+// Refresh reloads the catalog if the seed generation changed since the last load.
+func (c *ContentCache) Refresh() error {
+	marker, err := database.CurrentSeed(c.db)
+	if err != nil {
+		return err
+	}
+	generation := marker.Generation
+
+	c.mu.RLock()
+	current := c.snapshot
+	c.mu.RUnlock()
+	if current != nil && current.generation == generation {
+		return nil
+	}
+
+	snapshot, err := c.load(generation)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.snapshot = snapshot
+	c.mu.Unlock()
+	contentCacheGeneration.Set(float64(generation))
+	logrus.WithFields(logrus.Fields{
+		"generation":  generation,
+		"source":      marker.Source,
+		"revision":    marker.Revision,
+		"quickstarts": len(snapshot.quickstarts),
+		"help_topics": len(snapshot.helpTopics),
+	}).Info("Content cache loaded")
+	return nil
+}
+
+func (c *ContentCache) load(generation int64) (*contentSnapshot, error) {
+	var quickstarts []models.Quickstart
+	if err := c.db.Preload("Tags").Order("id").Find(&quickstarts).Error; err != nil {
+		return nil, err
+	}
+	var helpTopics []models.HelpTopic
+	if err := c.db.Preload("Tags").Order("id").Find(&helpTopics).Error; err != nil {
+		return nil, err
+	}
+	var aliases []models.QuickstartAlias
+	if err := c.db.Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+
+	snapshot := &contentSnapshot{
+		generation:        generation,
+		quickstarts:       make([]cachedQuickstart, len(quickstarts)),
+		quickstartsByID:   make(map[uint]int, len(quickstarts)),
+		quickstartsByName: make(map[string]int, len(quickstarts)),
+		aliases:           make(map[string]string, len(aliases)),
+		helpTopics:        make([]cachedHelpTopic, len(helpTopics)),
+		helpTopicsByName:  make(map[string]int, len(helpTopics)),
+	}
+
+	for i, q := range quickstarts {
+		entry := cachedQuickstart{
+			displayName: strings.ToLower(quickstartDisplayName(q)),
+			tags:        indexTags(q.Tags),
+		}
+		// Database queries do not preload associations, so neither does the cache.
+		q.Tags = nil
+		entry.quickstart = q
+		snapshot.quickstarts[i] = entry
+		snapshot.quickstartsByID[q.ID] = i
+		snapshot.quickstartsByName[q.Name] = i
+	}
+
+	for _, alias := range aliases {
+		snapshot.aliases[alias.Name] = alias.QuickstartName
+	}
+
+	for i, h := range helpTopics {
+		entry := cachedHelpTopic{tags: indexTags(h.Tags)}
+		h.Tags = nil
+		entry.helpTopic = h
+		snapshot.helpTopics[i] = entry
+		snapshot.helpTopicsByName[h.Name] = i
+	}
+
+	return snapshot, nil
+}
+
+func (c *ContentCache) current() *contentSnapshot {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot
+}
+
+func quickstartDisplayName(q models.Quickstart) string {
+	var content struct {
+		Spec struct {
+			DisplayName string `json:"displayName"`
+		} `json:"spec"`
+	}
+	if q.Content == nil {
+		return ""
+	}
+	if err := json.Unmarshal(q.Content, &content); err != nil {
+		return ""
+	}
+	return content.Spec.DisplayName
+}
+
+func indexTags(tags []models.Tag) map[models.TagType]map[string]bool {
+	index := make(map[models.TagType]map[string]bool)
+	for _, t := range tags {
+		if index[t.Type] == nil {
+			index[t.Type] = make(map[string]bool)
+		}
+		index[t.Type][t.Value] = true
+	}
+	return index
+}
+
+// matchesTags reports whether every requested tag type has at least one
+// matching value, mirroring the HAVING COUNT(DISTINCT t.type) query.
+func matchesTags(index map[models.TagType]map[string]bool, tagTypes []models.TagType, tagValues [][]string) bool {
+	for i, tt := range tagTypes {
+		values := index[tt]
+		found := false
+		for _, v := range tagValues[i] {
+			if values[v] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit != -1 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// findQuickstartByID returns the cached quickstart with the given ID.
+func (c *ContentCache) findQuickstartByID(id int) (models.Quickstart, bool, error) {
+	snapshot := c.current()
+	if snapshot == nil {
+		return models.Quickstart{}, false, nil
+	}
+	i, ok := snapshot.quickstartsByID[uint(id)]
+	if !ok {
+		return models.Quickstart{}, true, gorm.ErrRecordNotFound
+	}
+	return snapshot.quickstarts[i].quickstart, true, nil
+}
+
+// resolveAlias returns the current name of the quickstart previously called
+// name.
+func (c *ContentCache) resolveAlias(name string) (string, bool, error) {
+	snapshot := c.current()
+	if snapshot == nil {
+		return "", false, nil
+	}
+	current, ok := snapshot.aliases[name]
+	if !ok {
+		return "", true, gorm.ErrRecordNotFound
+	}
+	return current, true, nil
+}
+
+// findQuickstarts answers the non-fuzzy quickstart queries. The boolean is
+// false when the cache has not been loaded and the caller must query the
+// database instead.
+func (c *ContentCache) findQuickstarts(
+	tagTypes []models.TagType,
+	tagValues [][]string,
+	name, displayName string,
+	limit, offset int,
+	includeDrafts bool,
+) ([]models.Quickstart, bool) {
+	snapshot := c.current()
+	if snapshot == nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	result := []models.Quickstart{}
+	if name != "" {
+		if i, ok := snapshot.quickstartsByName[name]; ok && (includeDrafts || snapshot.quickstarts[i].quickstart.Visible(now)) {
+			result = append(result, snapshot.quickstarts[i].quickstart)
+		}
+		return result, true
+	}
+
+	needle := strings.ToLower(displayName)
+	for _, entry := range snapshot.quickstarts {
+		if !includeDrafts && !entry.quickstart.Visible(now) {
+			continue
+		}
+		if len(tagTypes) > 0 && !matchesTags(entry.tags, tagTypes, tagValues) {
+			continue
+		}
+		if needle != "" && !strings.Contains(entry.displayName, needle) {
+			continue
+		}
+		result = append(result, entry.quickstart)
+	}
+	return paginate(result, limit, offset), true
+}
+
+// findHelpTopicByName returns the cached help topic with the given name.
+func (c *ContentCache) findHelpTopicByName(name string) (models.HelpTopic, bool, error) {
+	snapshot := c.current()
+	if snapshot == nil {
+		return models.HelpTopic{}, false, nil
+	}
+	i, ok := snapshot.helpTopicsByName[name]
+	if !ok {
+		return models.HelpTopic{}, true, gorm.ErrRecordNotFound
+	}
+	return snapshot.helpTopics[i].helpTopic, true, nil
+}
+
+// findHelpTopics answers HelpTopicFilter queries from memory.
+func (c *ContentCache) findHelpTopics(f HelpTopicFilter) ([]models.HelpTopic, bool) {
+	snapshot := c.current()
+	if snapshot == nil {
+		return nil, false
+	}
+
+	names := make(map[string]bool, len(f.Names))
+	for _, n := range f.Names {
+		names[n] = true
+	}
+
+	// Sort tag types so matching is deterministic regardless of map order.
+	var tagTypes []models.TagType
+	var tagValues [][]string
+	for tt, values := range f.Tags {
+		if len(values) == 0 {
+			continue
+		}
+		tagTypes = append(tagTypes, tt)
+	}
+	sort.Slice(tagTypes, func(i, j int) bool { return tagTypes[i] < tagTypes[j] })
+	for _, tt := range tagTypes {
+		tagValues = append(tagValues, f.Tags[tt])
+	}
+
+	now := time.Now()
+	result := []models.HelpTopic{}
+	for _, entry := range snapshot.helpTopics {
+		if !f.IncludeDrafts && !entry.helpTopic.Visible(now) {
+			continue
+		}
+		if len(names) > 0 && !names[entry.helpTopic.Name] {
+			continue
+		}
+		if !matchesTags(entry.tags, tagTypes, tagValues) {
+			continue
+		}
+		result = append(result, entry.helpTopic)
+	}
+	return result, true
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupCacheContent(t *testing.T) {
+	rhel := models.Tag{Type: models.BundleTag, Value: "cache-rhel"}
+	settings := models.Tag{Type: models.BundleTag, Value: "cache-settings"}
+	iam := models.Tag{Type: models.ProductFamilies, Value: "cache-iam"}
+	for _, tag := range []*models.Tag{&rhel, &settings, &iam} {
+		assert.NoError(t, database.DB.Create(tag).Error)
+	}
+
+	quickstarts := []models.Quickstart{
+		{Name: "cache-first", Content: []byte(`{"spec":{"displayName":"First Steps"}}`), Tags: []models.Tag{rhel, iam}},
+		{Name: "cache-second", Content: []byte(`{"spec":{"displayName":"Second Steps"}}`), Tags: []models.Tag{settings}},
+		{Name: "cache-third", Content: []byte(`{"spec":{"displayName":"Something Else"}}`), Tags: []models.Tag{rhel}},
+		{Name: "cache-draft", Content: []byte(`{"spec":{"displayName":"Draft Steps"}}`), Tags: []models.Tag{rhel}, Lifecycle: models.Lifecycle{Status: models.StatusDraft}},
+	}
+	for i := range quickstarts {
+		assert.NoError(t, database.DB.Create(&quickstarts[i]).Error)
+	}
+
+	assert.NoError(t, database.DB.Create(&models.QuickstartAlias{Name: "cache-second-old", QuickstartName: "cache-second"}).Error)
+
+	helpTopics := []models.HelpTopic{
+		{Name: "cache-topic-a", GroupName: "cache", Content: []byte(`{}`), Tags: []models.Tag{rhel}},
+		{Name: "cache-topic-b", GroupName: "cache", Content: []byte(`{}`), Tags: []models.Tag{settings}},
+		{Name: "cache-topic-draft", GroupName: "cache", Content: []byte(`{}`), Tags: []models.Tag{settings}, Lifecycle: models.Lifecycle{Status: models.StatusDraft}},
+	}
+	for i := range helpTopics {
+		assert.NoError(t, database.DB.Create(&helpTopics[i]).Error)
+	}
+}
+
+func quickstartNames(items []models.Quickstart) []string {
+	names := make([]string, len(items))
+	for i, q := range items {
+		names[i] = q.Name
+	}
+	return names
+}
+
+func TestContentCache(t *testing.T) {
+	setupCacheContent(t)
+
+	cache := NewContentCache(database.DB)
+	assert.NoError(t, cache.Refresh())
+
+	cached := &QuickstartService{repo: testRepos.Quickstarts, cache: cache}
+	uncached := &QuickstartService{repo: testRepos.Quickstarts}
+
+	t.Run("tag filters match the database query", func(t *testing.T) {
+		tagTypes := []models.TagType{models.BundleTag, models.ProductFamilies}
+		tagValues := [][]string{{"cache-rhel", "cache-settings"}, {"cache-iam"}}
+
+		fromCache, err := cached.Find(tagTypes, tagValues, "", "", -1, 0)
+		assert.NoError(t, err)
+		fromDB, err := uncached.Find(tagTypes, tagValues, "", "", -1, 0)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"cache-first"}, quickstartNames(fromCache))
+		assert.ElementsMatch(t, quickstartNames(fromDB), quickstartNames(fromCache))
+	})
+
+	t.Run("display name filter is case insensitive", func(t *testing.T) {
+		result, err := cached.Find(nil, nil, "", "steps", -1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cache-first", "cache-second"}, quickstartNames(result))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		result, err := cached.Find(nil, nil, "", "", 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cache-second"}, quickstartNames(result))
+
+		result, err = cached.Find(nil, nil, "", "", 10, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("lookup by id and name", func(t *testing.T) {
+		result, err := cached.Find(nil, nil, "cache-third", "", 50, 0)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+
+		byID, err := cached.FindById(int(result[0].ID))
+		assert.NoError(t, err)
+		assert.Equal(t, "cache-third", byID.Name)
+
+		_, err = cached.FindById(999999)
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("lookup by previous name", func(t *testing.T) {
+		for _, service := range []*QuickstartService{cached, uncached} {
+			result, err := service.Find(nil, nil, "cache-second-old", "", 50, 0)
+			assert.NoError(t, err)
+			if assert.Len(t, result, 1) {
+				assert.Equal(t, "cache-second", result[0].Name)
+				assert.Equal(t, "cache-second-old", result[0].RedirectedFrom)
+			}
+
+			name, err := service.CurrentName("cache-second-old")
+			assert.NoError(t, err)
+			assert.Equal(t, "cache-second", name)
+			name, err = service.CurrentName("cache-missing")
+			assert.NoError(t, err)
+			assert.Equal(t, "cache-missing", name, "names that were never renamed are kept")
+		}
+	})
+
+	t.Run("help topic filters", func(t *testing.T) {
+		helpTopics := &HelpTopicService{repo: testRepos.HelpTopics, cache: cache}
+		result, err := helpTopics.FindWithFilters([]string{"cache-settings"}, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "cache-topic-b", result[0].Name)
+
+		topic, err := helpTopics.FindByName("cache-topic-a")
+		assert.NoError(t, err)
+		assert.Equal(t, "cache", topic.GroupName)
+
+		_, err = helpTopics.FindByName("missing")
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	})
+
+	t.Run("drafts are hidden unless requested", func(t *testing.T) {
+		var draft models.Quickstart
+		assert.NoError(t, database.DB.Where("name = ?", "cache-draft").First(&draft).Error)
+		for _, service := range []*QuickstartService{cached, uncached} {
+			result, err := service.Find(nil, nil, "cache-draft", "", 50, 0)
+			assert.NoError(t, err)
+			assert.Empty(t, result)
+			_, err = service.FindById(int(draft.ID))
+			assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+			result, err = service.WithDrafts().Find([]models.TagType{models.BundleTag}, [][]string{{"cache-rhel"}}, "", "", -1, 0)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{"cache-first", "cache-third", "cache-draft"}, quickstartNames(result))
+			_, err = service.WithDrafts().FindById(int(draft.ID))
+			assert.NoError(t, err)
+		}
+
+		helpTopics := &HelpTopicService{repo: testRepos.HelpTopics, cache: cache}
+		_, err := helpTopics.FindByName("cache-topic-draft")
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+		_, err = helpTopics.WithDrafts().FindByName("cache-topic-draft")
+		assert.NoError(t, err)
+		result, err := helpTopics.WithDrafts().FindWithFilters([]string{"cache-settings"}, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("reloads when the seed generation changes", func(t *testing.T) {
+		extra := models.Quickstart{Name: "cache-late", Content: []byte(`{"spec":{"displayName":"Late Arrival"}}`)}
+		assert.NoError(t, database.DB.Create(&extra).Error)
+
+		assert.NoError(t, cache.Refresh())
+		result, _ := cached.Find(nil, nil, "cache-late", "", 50, 0)
+		assert.Empty(t, result, "cache must not reload without a generation change")
+
+		marker := models.SeedGeneration{ID: models.SeedGenerationID, Generation: 7}
+		assert.NoError(t, database.DB.Save(&marker).Error)
+
+		assert.NoError(t, cache.Refresh())
+		result, _ = cached.Find(nil, nil, "cache-late", "", 50, 0)
+		assert.Len(t, result, 1)
+	})
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/datatypes"
+)
+
+const (
+	maxProjectionFields = 32
+	maxProjectionDepth  = 5
+)
+
+// projectionSegment restricts path segments to characters that are safe to
+// inline into SQL JSON path expressions.
+var projectionSegment = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var (
+	// QuickstartSummaryFields are the content paths catalog cards render.
+	QuickstartSummaryFields = []string{
+		"metadata.name",
+		"metadata.tags",
+		"spec.displayName",
+		"spec.description",
+		"spec.type",
+	}
+	// HelpTopicSummaryFields are the content paths help topic lists render.
+	HelpTopicSummaryFields = []string{
+		"name",
+		"title",
+		"tags",
+	}
+)
+
+// projectionNode is one level of the requested content tree. A node without
+// children selects the whole value at that path.
+type projectionNode struct {
+	children map[string]*projectionNode
+}
+
+// ContentProjection selects a subset of a JSON content column. It is built
+// from dot separated paths such as "spec.displayName" and can be rendered as
+// a SQL expression, so the database only ships the requested fields, or
+// applied in memory to already loaded content.
+type ContentProjection struct {
+	root *projectionNode
+}
+
+// NewContentProjection validates fields and builds a projection. Paths that
+// are prefixes of other paths select the whole subtree.
+func NewContentProjection(fields []string) (*ContentProjection, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+	if len(fields) > maxProjectionFields {
+		return nil, fmt.Errorf("at most %d fields may be requested", maxProjectionFields)
+	}
+
+	root := &projectionNode{children: map[string]*projectionNode{}}
+	for _, field := range fields {
+		segments := strings.Split(strings.TrimSpace(field), ".")
+		if len(segments) > maxProjectionDepth {
+			return nil, fmt.Errorf("field %q is nested deeper than %d levels", field, maxProjectionDepth)
+		}
+		for _, segment := range segments {
+			if !projectionSegment.MatchString(segment) {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+		}
+
+		node := root
+		for i, segment := range segments {
+			child, ok := node.children[segment]
+			if ok && child.children == nil {
+				// A shorter path already selects this whole subtree.
+				break
+			}
+			if !ok {
+				child = &projectionNode{children: map[string]*projectionNode{}}
+				node.children[segment] = child
+			}
+			if i == len(segments)-1 {
+				child.children = nil
+			}
+			node = child
+		}
+	}
+
+	return &ContentProjection{root: root}, nil
+}
+
+func mustContentProjection(fields []string) *ContentProjection {
+	p, err := NewContentProjection(fields)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// QuickstartSummaryProjection returns the projection used by view=summary on quickstarts.
+func QuickstartSummaryProjection() *ContentProjection {
+	return mustContentProjection(QuickstartSummaryFields)
+}
+
+// HelpTopicSummaryProjection returns the projection used by view=summary on help topics.
+func HelpTopicSummaryProjection() *ContentProjection {
+	return mustContentProjection(HelpTopicSummaryFields)
+}
+
+func sortedKeys(children map[string]*projectionNode) []string {
+	keys := make([]string, 0, len(children))
+	for k := range children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SQL renders the projection as an expression over column for the given
+// GORM dialect. Missing paths project to JSON null.
+func (p *ContentProjection) SQL(dialect, column string) string {
+	return p.sqlNode(dialect, column, p.root, nil)
+}
+
+func (p *ContentProjection) sqlNode(dialect, column string, node *projectionNode, path []string) string {
+	keys := sortedKeys(node.children)
+	args := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		child := node.children[key]
+		childPath := append(append([]string{}, path...), key)
+		var value string
+		if child.children == nil {
+			value = extractSQL(dialect, column, childPath)
+		} else {
+			value = p.sqlNode(dialect, column, child, childPath)
+		}
+		args = append(args, "'"+key+"'", value)
+	}
+
+	if dialect == "postgres" {
+		return "jsonb_build_object(" + strings.Join(args, ", ") + ")"
+	}
+	return "json_object(" + strings.Join(args, ", ") + ")"
+}
+
+func extractSQL(dialect, column string, path []string) string {
+	if dialect == "postgres" {
+		return column + "->'" + strings.Join(path, "'->'") + "'"
+	}
+	// SQLite: "->" returns the JSON text of the value; json() keeps it JSON
+	// typed when nested inside json_object.
+	return `json(` + column + ` -> '$."` + strings.Join(path, `"."`) + `"')`
+}
+
+// Apply projects already loaded content in memory. Content that cannot be
+// decoded is returned unchanged.
+func (p *ContentProjection) Apply(content datatypes.JSON) datatypes.JSON {
+	if content == nil {
+		return content
+	}
+	var doc interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return content
+	}
+	projected, err := json.Marshal(applyNode(p.root, doc))
+	if err != nil {
+		return content
+	}
+	return projected
+}
+
+func applyNode(node *projectionNode, value interface{}) map[string]interface{} {
+	obj, _ := value.(map[string]interface{})
+	out := make(map[string]interface{}, len(node.children))
+	for key, child := range node.children {
+		var v interface{}
+		if obj != nil {
+			v = obj[key]
+		}
+		if child.children == nil {
+			out[key] = v
+		} else {
+			out[key] = applyNode(child, v)
+		}
+	}
+	return out
+}
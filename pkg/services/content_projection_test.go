@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContentProjection(t *testing.T) {
+	t.Run("rejects unsafe paths", func(t *testing.T) {
+		for _, field := range []string{"spec.display'Name", "", "spec..name", "a.b.c.d.e.f"} {
+			_, err := NewContentProjection([]string{field})
+			assert.Error(t, err, field)
+		}
+	})
+
+	t.Run("rejects empty field list", func(t *testing.T) {
+		_, err := NewContentProjection(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("shorter paths select the whole subtree", func(t *testing.T) {
+		content := []byte(`{"spec":{"displayName":"A","description":"B"},"metadata":{"name":"n"}}`)
+		for _, fields := range [][]string{{"spec", "spec.displayName"}, {"spec.displayName", "spec"}} {
+			p, err := NewContentProjection(fields)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"spec":{"displayName":"A","description":"B"}}`, string(p.Apply(content)))
+		}
+	})
+
+	t.Run("missing paths project to null", func(t *testing.T) {
+		p, err := NewContentProjection([]string{"spec.icon", "metadata.name"})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"icon":null},"metadata":{"name":"n"}}`, string(p.Apply([]byte(`{"metadata":{"name":"n"}}`))))
+	})
+}
+
+func TestQuickstartServiceProjection(t *testing.T) {
+	content := `{"metadata":{"name":"projected","tags":[{"kind":"bundle","value":"rhel"}]},` +
+		`"spec":{"displayName":"Projected","description":"Short","type":{"text":"Quick start","color":"green"},` +
+		`"tasks":[{"title":"Long task body"}]}}`
+	q := models.Quickstart{Name: "projected", Content: []byte(content)}
+	assert.NoError(t, database.DB.Create(&q).Error)
+
+	expected := `{"metadata":{"name":"projected","tags":[{"kind":"bundle","value":"rhel"}]},` +
+		`"spec":{"displayName":"Projected","description":"Short","type":{"text":"Quick start","color":"green"}}}`
+
+	t.Run("database projection", func(t *testing.T) {
+		service := (&QuickstartService{repo: testRepos.Quickstarts}).WithProjection(QuickstartSummaryProjection())
+		result, err := service.Find(nil, nil, "projected", "", 50, 0)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, q.ID, result[0].ID)
+		assert.JSONEq(t, expected, string(result[0].Content))
+	})
+
+	t.Run("cached projection", func(t *testing.T) {
+		cache := NewContentCache(database.DB)
+		assert.NoError(t, cache.Refresh())
+		service := (&QuickstartService{repo: testRepos.Quickstarts, cache: cache}).WithProjection(QuickstartSummaryProjection())
+		result, err := service.Find(nil, nil, "projected", "", 50, 0)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.JSONEq(t, expected, string(result[0].Content))
+
+		// The projection must not leak into the cached copy.
+		full, _ := (&QuickstartService{repo: testRepos.Quickstarts, cache: cache}).Find(nil, nil, "projected", "", 50, 0)
+		var doc map[string]map[string]interface{}
+		assert.NoError(t, json.Unmarshal(full[0].Content, &doc))
+		assert.Contains(t, doc["spec"], "tasks")
+	})
+
+	t.Run("help topic projection", func(t *testing.T) {
+		topic := models.HelpTopic{Name: "projected-topic", GroupName: "projected", Content: []byte(`{"name":"projected-topic","title":"T","content":"long body","tags":["a"]}`)}
+		assert.NoError(t, database.DB.Create(&topic).Error)
+
+		service := (&HelpTopicService{repo: testRepos.HelpTopics}).WithProjection(HelpTopicSummaryProjection())
+		result, err := service.FindWithFilters(nil, nil, []string{"projected-topic"})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "projected", result[0].GroupName)
+		assert.JSONEq(t, `{"name":"projected-topic","title":"T","tags":["a"]}`, string(result[0].Content))
+	})
+}
@@ -1,41 +1,51 @@
 package services
 
 import (
-	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"context"
+	"errors"
+
 	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 	"github.com/sirupsen/logrus"
 )
 
 // FavoriteService handles business logic for favorite quickstarts
-type FavoriteService struct{}
+type FavoriteService struct {
+	ctx  context.Context
+	repo repository.FavoriteRepository
+}
 
 // NewFavoriteService creates a new favorite service
-func NewFavoriteService() *FavoriteService {
-	return &FavoriteService{}
+func NewFavoriteService(repo repository.FavoriteRepository) *FavoriteService {
+	return &FavoriteService{repo: repo}
+}
+
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *FavoriteService) WithContext(ctx context.Context) *FavoriteService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
 }
 
 // GetFavorites gets all favorite quickstarts for a specific account
 func (s *FavoriteService) GetFavorites(accountId string) ([]models.FavoriteQuickstart, error) {
-	var favQuickstarts []models.FavoriteQuickstart
-	result := database.DB.Where(&models.FavoriteQuickstart{AccountId: accountId, Favorite: true}).Find(&favQuickstarts)
-	return favQuickstarts, result.Error
+	return s.repo.FindFavorites(s.ctx, accountId)
 }
 
 // SwitchFavorite toggles the favorite status for a quickstart
 func (s *FavoriteService) SwitchFavorite(accountId string, quickstartName string, favorite bool) (models.FavoriteQuickstart, error) {
-	var favQuickstart models.FavoriteQuickstart
-
 	// First, find if the record exists
-	findResult := database.DB.Where("account_id = ? AND quickstart_name = ?", accountId, quickstartName).First(&favQuickstart)
-
-	if findResult.Error == nil {
+	favQuickstart, err := s.repo.Find(s.ctx, accountId, quickstartName)
+	if err == nil {
 		// Record exists, update it
-		result := database.DB.Model(&favQuickstart).Update("favorite", favorite)
-		if result.Error != nil {
-			return favQuickstart, result.Error
+		if err := s.repo.SetFavorite(s.ctx, &favQuickstart, favorite); err != nil {
+			return favQuickstart, err
 		}
 		return favQuickstart, nil
 	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return favQuickstart, err
+	}
 
 	// Record doesn't exist, create a new one
 	favQuickstart = models.FavoriteQuickstart{
@@ -43,13 +53,8 @@ func (s *FavoriteService) SwitchFavorite(accountId string, quickstartName string
 		QuickstartName: quickstartName,
 		Favorite:       favorite,
 	}
-
-	var qs models.Quickstart
-	database.DB.Where("name = ?", quickstartName).Preload("FavoriteQuickstart").Find(&qs)
-	qs.FavoriteQuickstart = append(qs.FavoriteQuickstart, favQuickstart)
-
-	if err := database.DB.Save(&qs).Error; err != nil {
-		logrus.Errorln("Error saving to database Quickstart:", err)
+	if err := s.repo.Create(s.ctx, &favQuickstart); err != nil {
+		logrus.Errorln("Error saving favorite quickstart:", err)
 		return favQuickstart, err
 	}
 
@@ -1,70 +1,99 @@
 package services
 
 import (
-	"fmt"
-	"strings"
+	"context"
+	"time"
 
-	"github.com/RedHatInsights/quickstarts/pkg/database"
 	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 )
 
 // HelpTopicService handles business logic for help topics
-type HelpTopicService struct{}
+type HelpTopicService struct {
+	ctx           context.Context
+	repo          repository.HelpTopicRepository
+	cache         *ContentCache
+	projection    *ContentProjection
+	includeDrafts bool
+}
 
 // HelpTopicFilter holds any combination of name‐ and tag‐based filters.
-type HelpTopicFilter struct {
-	Names []string
-	Tags  map[models.TagType][]string
-}
+type HelpTopicFilter = repository.HelpTopicFilter
 
 // NewHelpTopicService creates a new help topic service
-func NewHelpTopicService() *HelpTopicService {
-	return &HelpTopicService{}
+func NewHelpTopicService(repo repository.HelpTopicRepository) *HelpTopicService {
+	return &HelpTopicService{repo: repo, cache: contentCache}
 }
 
-// FindByFilter runs one query, joining in exactly as many tag‐filters as you need.
-func (s *HelpTopicService) FindByFilter(f HelpTopicFilter) ([]models.HelpTopic, error) {
-	db := database.DB.Model(&models.HelpTopic{})
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *HelpTopicService) WithContext(ctx context.Context) *HelpTopicService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
 
-	// name filter
-	if len(f.Names) > 0 {
-		db = db.Where("help_topics.name IN ?", f.Names)
-	}
+// WithProjection returns a copy of the service whose list queries only return
+// the content fields selected by projection. A nil projection returns full content.
+func (s *HelpTopicService) WithProjection(projection *ContentProjection) *HelpTopicService {
+	scoped := *s
+	scoped.projection = projection
+	return &scoped
+}
+
+// WithDrafts returns a copy of the service that also finds drafts and help
+// topics outside their publishing window. Callers must restrict it to
+// internal users.
+func (s *HelpTopicService) WithDrafts() *HelpTopicService {
+	scoped := *s
+	scoped.includeDrafts = true
+	return &scoped
+}
 
-	// dynamic joins for each tag type through help_topic_tags junction table
-	for tagType, values := range f.Tags {
-		if len(values) == 0 {
-			continue
+// FindByFilter returns the help topics matching every filter in f.
+func (s *HelpTopicService) FindByFilter(f HelpTopicFilter) ([]models.HelpTopic, error) {
+	f.IncludeDrafts = f.IncludeDrafts || s.includeDrafts
+	if s.cache != nil {
+		if helpTopics, ok := s.cache.findHelpTopics(f); ok {
+			contentCacheHits.WithLabelValues("helptopics").Inc()
+			if s.projection != nil {
+				for i := range helpTopics {
+					helpTopics[i].Content = s.projection.Apply(helpTopics[i].Content)
+				}
+			}
+			return helpTopics, nil
 		}
-		alias := fmt.Sprintf("t_%s", strings.ToLower(string(tagType)))
-		junctionAlias := fmt.Sprintf("htt_%s", strings.ToLower(string(tagType)))
-		db = db.
-			Joins(
-				fmt.Sprintf(
-					"JOIN help_topic_tags %s ON %s.help_topic_id = help_topics.id",
-					junctionAlias, junctionAlias,
-				),
-			).
-			Joins(
-				fmt.Sprintf(
-					"JOIN tags %s ON %s.tag_id = %s.id AND %s.type = ? AND %s.value IN ?",
-					alias, junctionAlias, alias, alias, alias,
-				),
-				tagType, values,
-			)
+		contentCacheMisses.WithLabelValues("helptopics").Inc()
 	}
 
-	var result []models.HelpTopic
-	return result, db.Find(&result).Error
+	// A nil *ContentProjection must stay a nil interface.
+	var projection repository.Projection
+	if s.projection != nil {
+		projection = s.projection
+	}
+	return s.repo.Find(s.ctx, f, projection)
 }
 
-// FindByName finds a help topic by name
+// FindByName finds a help topic by name. Unpublished help topics are not
+// found without drafts.
 func (s *HelpTopicService) FindByName(name string) (models.HelpTopic, error) {
-	var helpTopic models.HelpTopic
-	err := database.DB.Where("name = ?", name).First(&helpTopic).Error
+	helpTopic, err := s.findByName(name)
+	if err == nil && !s.includeDrafts && !helpTopic.Visible(time.Now()) {
+		return models.HelpTopic{}, repository.ErrNotFound
+	}
 	return helpTopic, err
 }
 
+func (s *HelpTopicService) findByName(name string) (models.HelpTopic, error) {
+	if s.cache != nil {
+		if helpTopic, ok, err := s.cache.findHelpTopicByName(name); ok {
+			contentCacheHits.WithLabelValues("helptopic_by_name").Inc()
+			return helpTopic, err
+		}
+		contentCacheMisses.WithLabelValues("helptopic_by_name").Inc()
+	}
+
+	return s.repo.FindByName(s.ctx, name)
+}
 
 // FindWithFilters finds help topics with bundle, application, and name filters
 func (s *HelpTopicService) FindWithFilters(
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/config"
+	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+)
+
+func TestMain(m *testing.M) {
+	setUp()
+	retCode := m.Run()
+	tearDown()
+	os.Exit(retCode)
+}
+
+var dbName string
+
+// testRepos are the database-backed repositories used by the service tests.
+var testRepos repository.Repositories
+
+func setUp() {
+	config.Init()
+	cfg := config.Get()
+	cfg.Test = true
+
+	if testDBURL := os.Getenv("TEST_DATABASE_URL"); testDBURL != "" {
+		cfg.TestDatabaseURL = testDBURL
+	} else {
+		time := time.Now().UnixNano()
+		dbName = fmt.Sprintf("%d-services.db", time)
+		cfg.DbName = dbName
+	}
+
+	database.Init()
+	err := database.MigrateTestSchema(database.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	// Ensure clean state for PostgreSQL (SQLite creates a fresh file each run)
+	if err := database.CleanTestTables(); err != nil {
+		panic(fmt.Sprintf("CleanTestTables failed: %s", err.Error()))
+	}
+	testRepos = repository.NewGorm(database.DB, database.IsFuzzySearchSupported())
+}
+
+func tearDown() {
+	if dbName != "" {
+		os.Remove(dbName)
+	}
+}
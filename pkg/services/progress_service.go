@@ -1,51 +1,44 @@
 package services
 
 import (
-	"github.com/RedHatInsights/quickstarts/pkg/database"
+	"context"
+
 	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
 	"gorm.io/datatypes"
 )
 
 // ProgressService handles business logic for quickstart progress
-type ProgressService struct{}
+type ProgressService struct {
+	ctx  context.Context
+	repo repository.ProgressRepository
+}
 
 // NewProgressService creates a new progress service
-func NewProgressService() *ProgressService {
-	return &ProgressService{}
+func NewProgressService(repo repository.ProgressRepository) *ProgressService {
+	return &ProgressService{repo: repo}
+}
+
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *ProgressService) WithContext(ctx context.Context) *ProgressService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
 }
 
 // GetExistingProgress finds existing progress record by name and account ID
 func (s *ProgressService) GetExistingProgress(name string, accountId int) (models.QuickstartProgress, error) {
-	var progress models.QuickstartProgress
-	var where models.QuickstartProgress
-	where.QuickstartName = name
-	where.AccountId = accountId
-	err := database.DB.Where(where).First(&progress).Error
-	return progress, err
+	return s.repo.FindOne(s.ctx, accountId, name)
 }
 
 // GetAllProgress returns all progress records
 func (s *ProgressService) GetAllProgress() ([]models.QuickstartProgress, error) {
-	var progress []models.QuickstartProgress
-	err := database.DB.Find(&progress).Error
-	return progress, err
+	return s.repo.Find(s.ctx, repository.ProgressFilter{})
 }
 
 // GetProgress returns progress records with optional filtering by account and/or quickstart name
 func (s *ProgressService) GetProgress(accountId *int, quickstartName *string) ([]models.QuickstartProgress, error) {
-	var progresses []models.QuickstartProgress
-	var where models.QuickstartProgress
-
-	if accountId != nil {
-		where.AccountId = *accountId
-	}
-
-	if quickstartName != nil {
-		where.QuickstartName = *quickstartName
-	}
-
-	err := database.DB.Where(where).Find(&progresses).Error
-	return progresses, err
+	return s.repo.Find(s.ctx, repository.ProgressFilter{AccountId: accountId, QuickstartName: quickstartName})
 }
 
 // UpdateProgress creates new progress or updates existing progress
@@ -59,27 +52,17 @@ func (s *ProgressService) UpdateProgress(accountId int, quickstartName string, p
 			QuickstartName: quickstartName,
 			Progress:       progress,
 		}
-		err = database.DB.Create(&newProgress).Error
+		err = s.repo.Save(s.ctx, &newProgress)
 		return newProgress, err
 	}
 
 	// Update existing progress
 	currentProgress.Progress = progress
-	err = database.DB.Save(&currentProgress).Error
+	err = s.repo.Save(s.ctx, &currentProgress)
 	return currentProgress, err
 }
 
 // DeleteProgress deletes progress record by ID
 func (s *ProgressService) DeleteProgress(id int) error {
-	var quickStartProgress models.QuickstartProgress
-
-	// First check if record exists
-	err := database.DB.First(&quickStartProgress, id).Error
-	if err != nil {
-		return err
-	}
-
-	// Delete the record
-	err = database.DB.Delete(&quickStartProgress).Error
-	return err
+	return s.repo.Delete(s.ctx, id)
 }
@@ -1,236 +1,196 @@
 package services
 
 import (
-	"fmt"
-	"strings"
+	"context"
+	"errors"
+	"time"
 
-	"github.com/RedHatInsights/quickstarts/config"
-	"github.com/RedHatInsights/quickstarts/pkg/database"
 	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+	p "github.com/prometheus/client_golang/prometheus"
+	pa "github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+var fuzzySearchFallbacks = pa.NewCounterVec(p.CounterOpts{
+	Name: "quickstarts_fuzzy_search_fallbacks_total",
+	Help: "Total number of fuzzy searches answered by the ILIKE fallback, by reason",
+}, []string{"reason"})
+
 // QuickstartService handles business logic for quickstarts
-type QuickstartService struct{}
+type QuickstartService struct {
+	ctx           context.Context
+	repo          repository.QuickstartRepository
+	cache         *ContentCache
+	projection    *ContentProjection
+	includeDrafts bool
+}
 
 // NewQuickstartService creates a new quickstart service
-func NewQuickstartService() *QuickstartService {
-	return &QuickstartService{}
+func NewQuickstartService(repo repository.QuickstartRepository) *QuickstartService {
+	return &QuickstartService{repo: repo, cache: contentCache}
 }
 
-// FindById finds a quickstart by ID
-func (s *QuickstartService) FindById(id int) (models.Quickstart, error) {
-	var quickStart models.Quickstart
-	err := database.DB.First(&quickStart, id).Error
-	return quickStart, err
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *QuickstartService) WithContext(ctx context.Context) *QuickstartService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
 }
 
-// FindByDisplayName finds quickstarts by display name with pagination
-func (s *QuickstartService) FindByDisplayName(displayName string, limit, offset int) ([]models.Quickstart, error) {
-	var quickStarts []models.Quickstart
-	query := database.DB.Offset(offset).Where("content->'spec'->>'displayName' ILIKE ?", "%"+displayName+"%")
+// WithProjection returns a copy of the service whose list queries only return
+// the content fields selected by projection. A nil projection returns full content.
+func (s *QuickstartService) WithProjection(projection *ContentProjection) *QuickstartService {
+	scoped := *s
+	scoped.projection = projection
+	return &scoped
+}
+
+// WithDrafts returns a copy of the service that also finds drafts and
+// quickstarts outside their publishing window. Callers must restrict it to
+// internal users.
+func (s *QuickstartService) WithDrafts() *QuickstartService {
+	scoped := *s
+	scoped.includeDrafts = true
+	return &scoped
+}
 
-	// Apply limit only if it's not -1 (which means no limit)
-	if limit != -1 {
-		query = query.Limit(limit)
+func (s *QuickstartService) query(tagTypes []models.TagType, tagValues [][]string, name, displayName string, limit, offset int) repository.QuickstartQuery {
+	q := repository.QuickstartQuery{
+		TagTypes:      tagTypes,
+		TagValues:     tagValues,
+		Name:          name,
+		DisplayName:   displayName,
+		Limit:         limit,
+		Offset:        offset,
+		IncludeDrafts: s.includeDrafts,
 	}
+	// A nil *ContentProjection must stay a nil interface.
+	if s.projection != nil {
+		q.Projection = s.projection
+	}
+	return q
+}
 
-	err := query.Find(&quickStarts).Error
-	return quickStarts, err
+// projectCached applies the projection to quickstarts served from the cache.
+func (s *QuickstartService) projectCached(quickstarts []models.Quickstart) []models.Quickstart {
+	if s.projection == nil {
+		return quickstarts
+	}
+	for i := range quickstarts {
+		quickstarts[i].Content = s.projection.Apply(quickstarts[i].Content)
+	}
+	return quickstarts
 }
 
-// FindByTagsAndDisplayName finds quickstarts by tags and display name with pagination
-func (s *QuickstartService) FindByTagsAndDisplayName(
-	tagTypes []models.TagType,
-	tagValues [][]string,
-	displayName string,
-	limit, offset int,
-) ([]models.Quickstart, error) {
-	var quickstarts []models.Quickstart
-
-	// build "(t.type = ? AND t.value IN (?)) OR …" and collect params
-	conds := make([]string, len(tagTypes))
-	params := make([]interface{}, 0, len(tagTypes)*2)
-	for i, tt := range tagTypes {
-		conds[i] = "(t.type = ? AND t.value IN (?))"
-		params = append(params, tt, tagValues[i])
-	}
-	whereClause := strings.Join(conds, " OR ")
-
-	query := database.DB.
-		Model(&models.Quickstart{}).
-		Joins("JOIN quickstart_tags qt ON qt.quickstart_id = quickstarts.id").
-		Joins("JOIN tags t ON t.id = qt.tag_id").
-		Where(whereClause, params...).
-		Group("quickstarts.id").
-		Having("COUNT(DISTINCT t.type) = ?", len(tagTypes))
-
-	if displayName != "" {
-		query = query.
-			Where("content->'spec'->>'displayName' ILIKE ?", "%"+displayName+"%")
-	}
-	query = query.Offset(offset)
-	if limit != -1 {
-		query = query.Limit(limit)
-	}
-
-	return quickstarts, query.Find(&quickstarts).Error
+// FindById finds a quickstart by ID. Unpublished quickstarts are not found
+// without drafts.
+func (s *QuickstartService) FindById(id int) (models.Quickstart, error) {
+	quickstart, err := s.findById(id)
+	if err == nil && !s.includeDrafts && !quickstart.Visible(time.Now()) {
+		return models.Quickstart{}, repository.ErrNotFound
+	}
+	return quickstart, err
 }
 
-// findFuzzy is a unified fuzzy search implementation that supports optional tag filtering
-// Pass nil/empty slices for tagTypes/tagValues when searching without tag filters
-func (s *QuickstartService) findFuzzy(
-	tagTypes []models.TagType,
-	tagValues [][]string,
-	searchTerm string,
-	limit, offset int,
-) ([]models.Quickstart, error) {
-	var quickstarts []models.Quickstart
-
-	// Check if fuzzy search is supported (PostgreSQL with fuzzystrmatch extension)
-	if !database.IsFuzzySearchSupported() {
-		// Fall back to regular ILIKE search
-		if len(tagTypes) > 0 {
-			return s.FindByTagsAndDisplayName(tagTypes, tagValues, searchTerm, limit, offset)
+func (s *QuickstartService) findById(id int) (models.Quickstart, error) {
+	if s.cache != nil {
+		if quickStart, ok, err := s.cache.findQuickstartByID(id); ok {
+			contentCacheHits.WithLabelValues("quickstart_by_id").Inc()
+			return quickStart, err
 		}
-		return s.FindByDisplayName(searchTerm, limit, offset)
+		contentCacheMisses.WithLabelValues("quickstart_by_id").Inc()
 	}
 
-	cfg := config.Get()
-	threshold := cfg.MaxFuzzySearchDistance
-
-	// Build the base query with optional tag filtering
-	var baseTableQuery string
-	var params []interface{}
-
-	// Start with searchTerm (used in query_words CTE)
-	params = append(params, searchTerm)
+	return s.repo.FindByID(s.ctx, id)
+}
 
-	if len(tagTypes) > 0 {
-		// Build tag filter conditions
-		conds := make([]string, len(tagTypes))
+// Find finds quickstarts based on various criteria. A name that matches no
+// quickstart but is the previous name of a renamed one finds that quickstart,
+// with RedirectedFrom set to name.
+func (s *QuickstartService) Find(tagTypes []models.TagType, tagValues [][]string, name string, displayName string, limit, offset int) ([]models.Quickstart, error) {
+	quickstarts, err := s.find(tagTypes, tagValues, name, displayName, limit, offset)
+	if err != nil || name == "" || len(quickstarts) > 0 {
+		return quickstarts, err
+	}
 
-		// Add tag parameters BEFORE threshold
-		for i, tt := range tagTypes {
-			conds[i] = "(t.type = ? AND t.value IN (?))"
-			params = append(params, tt, tagValues[i])
-		}
-		whereClause := strings.Join(conds, " OR ")
-
-		// CTE that filters quickstarts by tags first
-		baseTableQuery = `
-		tagged_quickstarts AS (
-			SELECT q.id, q.created_at, q.updated_at, q.deleted_at, q.name, q.content
-			FROM quickstarts q
-			JOIN quickstart_tags qt ON qt.quickstart_id = q.id
-			JOIN tags t ON t.id = qt.tag_id
-			WHERE ` + whereClause + `
-			GROUP BY q.id, q.created_at, q.updated_at, q.deleted_at, q.name, q.content
-			HAVING COUNT(DISTINCT t.type) = ` + fmt.Sprintf("%d", len(tagTypes)) + `
-		),`
-	} else {
-		baseTableQuery = ""
-	}
-
-	// Add threshold AFTER tag parameters (used in WHERE min_distance <= ?)
-	params = append(params, threshold)
-
-	// Determine which table to use in word_matches CTE
-	sourceTable := "quickstarts q"
-	sourceAlias := "q"
-	if len(tagTypes) > 0 {
-		sourceTable = "tagged_quickstarts tq"
-		sourceAlias = "tq"
-	}
-
-	// Word-by-word fuzzy matching with partial matches:
-	// 1. Split query into words
-	// 2. For each query word, find the best matching word in each display name
-	// 3. Return quickstarts that match at least one query word within threshold
-	// 4. Order by: number of matching words (DESC), then total distance (ASC)
-	sqlQuery := `
-		WITH query_words AS (
-			SELECT unnest(regexp_split_to_array(LOWER(?), '\s+')) as query_word
-		),
-		` + baseTableQuery + `
-		word_matches AS (
-			SELECT
-				` + sourceAlias + `.id,
-				` + sourceAlias + `.created_at,
-				` + sourceAlias + `.updated_at,
-				` + sourceAlias + `.deleted_at,
-				` + sourceAlias + `.name,
-				` + sourceAlias + `.content,
-				qw.query_word,
-				MIN(levenshtein(qw.query_word, display_word)) as min_distance
-			FROM query_words qw
-			CROSS JOIN ` + sourceTable + `
-			CROSS JOIN LATERAL unnest(regexp_split_to_array(LOWER(` + sourceAlias + `.content->'spec'->>'displayName'), '\s+')) as display_word
-			WHERE ` + sourceAlias + `.content->'spec'->>'displayName' IS NOT NULL
-			GROUP BY ` + sourceAlias + `.id, ` + sourceAlias + `.created_at, ` + sourceAlias + `.updated_at, ` + sourceAlias + `.deleted_at, ` + sourceAlias + `.name, ` + sourceAlias + `.content, qw.query_word
-		)
-		SELECT
-			id, created_at, updated_at, deleted_at, name, content,
-			COUNT(*) as match_count,
-			SUM(min_distance) as total_distance
-		FROM word_matches
-		WHERE min_distance <= ?
-		GROUP BY id, created_at, updated_at, deleted_at, name, content
-		ORDER BY match_count DESC, total_distance ASC, content->'spec'->>'displayName' ASC`
-
-	var err error
-	if limit == -1 {
-		sqlQuery += ` OFFSET ?`
-		params = append(params, offset)
-	} else {
-		sqlQuery += ` LIMIT ? OFFSET ?`
-		params = append(params, limit, offset)
-	}
-
-	err = database.DB.Raw(sqlQuery, params...).Find(&quickstarts).Error
+	current, err := s.resolveAlias(name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return quickstarts, nil
+	}
 	if err != nil {
-		return quickstarts, err
+		return nil, err
+	}
+	quickstarts, err = s.find(tagTypes, tagValues, current, displayName, limit, offset)
+	for i := range quickstarts {
+		quickstarts[i].RedirectedFrom = name
 	}
+	return quickstarts, err
+}
 
-	// Hybrid fallback: If no fuzzy results found, fall back to ILIKE for partial matching
-	if len(quickstarts) == 0 {
-		if len(tagTypes) > 0 {
-			return s.FindByTagsAndDisplayName(tagTypes, tagValues, searchTerm, limit, offset)
+func (s *QuickstartService) find(tagTypes []models.TagType, tagValues [][]string, name string, displayName string, limit, offset int) ([]models.Quickstart, error) {
+	if s.cache != nil {
+		if quickstarts, ok := s.cache.findQuickstarts(tagTypes, tagValues, name, displayName, limit, offset, s.includeDrafts); ok {
+			contentCacheHits.WithLabelValues("quickstarts").Inc()
+			return s.projectCached(quickstarts), nil
 		}
-		return s.FindByDisplayName(searchTerm, limit, offset)
+		contentCacheMisses.WithLabelValues("quickstarts").Inc()
 	}
 
-	return quickstarts, nil
+	return s.repo.Find(s.ctx, s.query(tagTypes, tagValues, name, displayName, limit, offset))
 }
 
-// Find finds quickstarts based on various criteria
-func (s *QuickstartService) Find(tagTypes []models.TagType, tagValues [][]string, name string, displayName string, limit, offset int) ([]models.Quickstart, error) {
-	var quickstarts []models.Quickstart
-	var err error
-
-	if name != "" {
-		err = database.DB.Where("name = ?", name).Find(&quickstarts).Error
-	} else if len(tagTypes) > 0 {
-		quickstarts, err = s.FindByTagsAndDisplayName(tagTypes, tagValues, displayName, limit, offset)
-	} else if displayName != "" {
-		quickstarts, err = s.FindByDisplayName(displayName, limit, offset)
-	} else {
-		query := database.DB.Offset(offset)
-		if limit != -1 {
-			query = query.Limit(limit)
+func (s *QuickstartService) resolveAlias(name string) (string, error) {
+	if s.cache != nil {
+		if current, ok, err := s.cache.resolveAlias(name); ok {
+			contentCacheHits.WithLabelValues("quickstart_alias").Inc()
+			return current, err
 		}
-		err = query.Find(&quickstarts).Error
+		contentCacheMisses.WithLabelValues("quickstart_alias").Inc()
 	}
 
-	return quickstarts, err
+	return s.repo.ResolveAlias(s.ctx, name)
+}
+
+// CurrentName returns the current name of the quickstart previously called
+// name, or name itself when no quickstart was renamed from it. Favorites and
+// progress are stored under current names.
+func (s *QuickstartService) CurrentName(name string) (string, error) {
+	current, err := s.resolveAlias(name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return name, nil
+	}
+	return current, err
 }
 
 // FindFuzzy finds quickstarts using fuzzy search with Levenshtein distance
 func (s *QuickstartService) FindFuzzy(tagTypes []models.TagType, tagValues [][]string, name string, searchTerm string, limit, offset int) ([]models.Quickstart, error) {
-	// Use fuzzy search when there's a search term or tag filters
-	if searchTerm != "" || len(tagTypes) > 0 {
-		return s.findFuzzy(tagTypes, tagValues, searchTerm, limit, offset)
+	// Without a search term or tag filters use the normal Find (exact name match, all quickstarts, etc.)
+	if searchTerm == "" && len(tagTypes) == 0 {
+		return s.Find(tagTypes, tagValues, name, "", limit, offset)
+	}
+
+	if s.cache != nil {
+		// Levenshtein ranking runs in the database, so fuzzy queries always miss.
+		contentCacheMisses.WithLabelValues("quickstarts_fuzzy").Inc()
 	}
 
-	// Otherwise fall back to normal Find (handles exact name match, all quickstarts, etc.)
-	return s.Find(tagTypes, tagValues, name, "", limit, offset)
+	// Fuzzy matching ignores the exact name filter, as does the ILIKE fallback.
+	q := s.query(tagTypes, tagValues, "", searchTerm, limit, offset)
+	quickstarts, err := s.repo.FindFuzzy(s.ctx, q)
+	if errors.Is(err, repository.ErrFuzzySearchUnsupported) {
+		// Fall back to regular ILIKE search
+		fuzzySearchFallbacks.WithLabelValues("unsupported").Inc()
+		return s.repo.Find(s.ctx, q)
+	}
+	if err != nil {
+		return quickstarts, err
+	}
+
+	// Hybrid fallback: If no fuzzy results found, fall back to ILIKE for partial matching
+	if len(quickstarts) == 0 {
+		fuzzySearchFallbacks.WithLabelValues("no_results").Inc()
+		return s.repo.Find(s.ctx, q)
+	}
+
+	return quickstarts, nil
 }
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/RedHatInsights/quickstarts/pkg/repository"
+)
+
+// SeedRunService reads the content seeding history
+type SeedRunService struct {
+	ctx  context.Context
+	repo repository.SeedRunRepository
+}
+
+// NewSeedRunService creates a new seed run service. repo may be nil when the
+// backend keeps no seed run history.
+func NewSeedRunService(repo repository.SeedRunRepository) *SeedRunService {
+	return &SeedRunService{repo: repo}
+}
+
+// WithContext returns a copy of the service whose queries run with ctx.
+func (s *SeedRunService) WithContext(ctx context.Context) *SeedRunService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
+
+// Enabled reports whether seed run history is available.
+func (s *SeedRunService) Enabled() bool {
+	return s.repo != nil
+}
+
+// Find returns seed runs, newest first, with pagination
+func (s *SeedRunService) Find(limit, offset int) ([]models.SeedRun, error) {
+	return s.repo.Find(s.ctx, limit, offset)
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"gorm.io/gorm"
+)
+
+// ErrorKind classifies an error into one of the problem types the API reports.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindValidation
+	KindNotFound
+	KindForbidden
+	KindPayloadTooLarge
+	KindUpstream
+	KindUpstreamTimeout
+)
+
+// problemType describes how an ErrorKind is rendered as problem details.
+type problemType struct {
+	uri    string
+	title  string
+	status int
+}
+
+var problemTypes = map[ErrorKind]problemType{
+	KindInternal:        {"urn:quickstarts:problem:internal", "Internal server error", http.StatusInternalServerError},
+	KindValidation:      {"urn:quickstarts:problem:validation", "Invalid request", http.StatusBadRequest},
+	KindNotFound:        {"urn:quickstarts:problem:not-found", "Resource not found", http.StatusNotFound},
+	KindForbidden:       {"urn:quickstarts:problem:forbidden", "Forbidden", http.StatusForbidden},
+	KindPayloadTooLarge: {"urn:quickstarts:problem:payload-too-large", "Request body too large", http.StatusRequestEntityTooLarge},
+	KindUpstream:        {"urn:quickstarts:problem:upstream", "Upstream service error", http.StatusBadGateway},
+	KindUpstreamTimeout: {"urn:quickstarts:problem:upstream-timeout", "Upstream service timeout", http.StatusGatewayTimeout},
+}
+
+// internalErrorDetail replaces the message of unclassified errors so database
+// and other internal details never reach clients.
+const internalErrorDetail = "an unexpected error occurred"
+
+// APIError is an error with a client-safe detail message. The wrapped error,
+// if any, is logged but never written to the response.
+type APIError struct {
+	Kind   ErrorKind
+	Detail string
+	Fields []generated.ProblemFieldError // individual validation failures, if any
+	Err    error
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Detail
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError is implemented by client errors that carry the status code
+// an upstream service responded with.
+type HTTPStatusError interface {
+	error
+	HTTPStatus() int
+	Detail() string
+}
+
+// ValidationError reports a problem with the client's request.
+func ValidationError(detail string) error {
+	return &APIError{Kind: KindValidation, Detail: detail}
+}
+
+// RequestValidationError reports a request that does not match the API
+// specification, listing each offending parameter or body field.
+func RequestValidationError(detail string, fields []generated.ProblemFieldError) error {
+	return &APIError{Kind: KindValidation, Detail: detail, Fields: fields}
+}
+
+// NotFoundError reports that the requested resource does not exist.
+func NotFoundError(detail string) error {
+	return &APIError{Kind: KindNotFound, Detail: detail}
+}
+
+// ForbiddenError reports that the caller may not perform the request.
+func ForbiddenError(detail string) error {
+	return &APIError{Kind: KindForbidden, Detail: detail}
+}
+
+// LookupError converts gorm.ErrRecordNotFound into a NotFoundError for
+// resource and returns any other error unchanged.
+func LookupError(resource string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &APIError{Kind: KindNotFound, Detail: resource + " not found", Err: err}
+	}
+	return err
+}
+
+// UpstreamError wraps a failed call to another service. Client errors the
+// upstream reported (400, 404) are passed through with their detail; all other
+// failures become a 502, or a 504 when the call timed out.
+func UpstreamError(service string, err error) error {
+	var statusErr HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.HTTPStatus() {
+		case http.StatusBadRequest:
+			return &APIError{Kind: KindValidation, Detail: statusErr.Detail(), Err: err}
+		case http.StatusNotFound:
+			return &APIError{Kind: KindNotFound, Detail: statusErr.Detail(), Err: err}
+		}
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &APIError{Kind: KindUpstreamTimeout, Detail: service + " request timed out", Err: err}
+	}
+	return &APIError{Kind: KindUpstream, Detail: service + " request failed", Err: err}
+}
+
+// classifyError maps err onto a problem type and a detail that is safe to
+// return to the client.
+func classifyError(err error) (problemType, string, []generated.ProblemFieldError) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return problemTypes[apiErr.Kind], apiErr.Detail, apiErr.Fields
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return problemTypes[KindNotFound], "resource not found", nil
+	case errors.As(err, &maxBytesErr):
+		return problemTypes[KindPayloadTooLarge], "request body too large", nil
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		// Decoding errors describe the client's payload, not server state.
+		return problemTypes[KindValidation], "invalid request body: " + err.Error(), nil
+	}
+	return problemTypes[KindInternal], internalErrorDetail, nil
+}
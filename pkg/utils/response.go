@@ -1,12 +1,23 @@
 package utils
 
 import (
+	"bufio"
 	"encoding/json"
 	"net/http"
 
 	"github.com/RedHatInsights/quickstarts/pkg/generated"
+	"github.com/redhatinsights/platform-go-middlewares/request_id"
+	"github.com/sirupsen/logrus"
 )
 
+// RequestIDHeader carries the platform request id, which error responses
+// return as the problem instance.
+const RequestIDHeader = "x-rh-insights-request-id"
+
+// streamBufferSize bounds how much encoded output is held in memory before it
+// is written to the client.
+const streamBufferSize = 32 * 1024
+
 // DataResponse creates a standardized JSON response with data
 func DataResponse[T any](w http.ResponseWriter, statusCode int, data T) {
 	w.Header().Set("Content-Type", "application/json")
@@ -15,6 +26,52 @@ func DataResponse[T any](w http.ResponseWriter, statusCode int, data T) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// StreamDataResponse writes the same {"data": [...]} envelope as DataResponse
+// but converts and encodes one item at a time, so the full API slice and its
+// encoding are never held in memory together. When timing is set, the
+// Server-Timing header carries the metrics collected so far and a trailer adds
+// the serialization time once the body is written.
+func StreamDataResponse[S any, T any](w http.ResponseWriter, statusCode int, timing *ServerTiming, items []S, toAPI func(S) T) {
+	w.Header().Set("Content-Type", "application/json")
+	if timing != nil {
+		w.Header().Set("Server-Timing", timing.String())
+		// Declaring the trailer up front forces a chunked response, which
+		// compressing writers would otherwise replace with a Content-Length.
+		w.Header().Set("Trailer", "Server-Timing")
+	}
+	w.WriteHeader(statusCode)
+
+	var stop func()
+	if timing != nil {
+		stop = timing.Start("serialize")
+	}
+
+	bw := bufio.NewWriterSize(w, streamBufferSize)
+	bw.WriteString(`{"data":[`)
+	for i, item := range items {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		encoded, err := json.Marshal(toAPI(item))
+		if err != nil {
+			// Headers are already sent; the truncated body signals the failure.
+			logrus.WithError(err).Error("Failed to encode streamed response item")
+			bw.Flush()
+			return
+		}
+		bw.Write(encoded)
+	}
+	bw.WriteString("]}\n")
+	bw.Flush()
+
+	if timing != nil {
+		stop()
+		// Values of declared trailers are read from the header map once the
+		// handler returns.
+		w.Header().Set("Server-Timing", timing.String())
+	}
+}
+
 // MessageResponse creates a standardized JSON response with a message
 func MessageResponse(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -23,23 +80,40 @@ func MessageResponse(w http.ResponseWriter, statusCode int, message string) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// ErrorResponse creates a standardized JSON error response
-func ErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	resp := generated.BadRequest{
-		Msg: &message,
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// ErrorResponse writes err as an RFC 7807 problem. The status and detail come
+// from the error taxonomy in errors.go; unclassified errors are reported as a
+// generic 500 and logged, so internal details never reach the client. The
+// request id is returned as the problem instance.
+func ErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	pt, detail, fields := classifyError(err)
+	instance := request_id.GetReqID(r.Context())
+	if instance == "" {
+		instance = r.Header.Get(RequestIDHeader)
 	}
-	json.NewEncoder(w).Encode(resp)
-}
 
-// NotFoundResponse creates a standardized 404 response
-func NotFoundResponse(w http.ResponseWriter, resource string) {
-	message := resource + " not found"
-	resp := generated.NotFound{
-		Msg: &message,
+	if pt.status >= http.StatusInternalServerError {
+		logrus.WithContext(r.Context()).WithError(err).WithFields(logrus.Fields{
+			"request_id": instance,
+			"status":     pt.status,
+		}).Error("Request failed")
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotFound)
+
+	resp := generated.Problem{
+		Type:   pt.uri,
+		Title:  pt.title,
+		Status: pt.status,
+		Detail: &detail,
+	}
+	if instance != "" {
+		resp.Instance = &instance
+	}
+	if len(fields) > 0 {
+		resp.Errors = &fields
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(pt.status)
 	json.NewEncoder(w).Encode(resp)
-}
\ No newline at end of file
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerTiming collects named durations reported to clients in the
+// Server-Timing header (https://www.w3.org/TR/server-timing/).
+type ServerTiming struct {
+	mu      sync.Mutex
+	metrics []timingMetric
+}
+
+type timingMetric struct {
+	name     string
+	duration time.Duration
+}
+
+// NewServerTiming creates an empty timing collector
+func NewServerTiming() *ServerTiming {
+	return &ServerTiming{}
+}
+
+// Start begins timing name and returns a function that records the elapsed
+// duration when called.
+func (t *ServerTiming) Start(name string) func() {
+	start := time.Now()
+	return func() {
+		t.Add(name, time.Since(start))
+	}
+}
+
+// Add records a duration under name
+func (t *ServerTiming) Add(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.metrics = append(t.metrics, timingMetric{name: name, duration: d})
+}
+
+// String formats the collected metrics as a Server-Timing header value with
+// durations in milliseconds.
+func (t *ServerTiming) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parts := make([]string, len(t.metrics))
+	for i, m := range t.metrics {
+		parts[i] = fmt.Sprintf("%s;dur=%.3f", m.name, float64(m.duration.Microseconds())/1000)
+	}
+	return strings.Join(parts, ", ")
+}
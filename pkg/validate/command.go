@@ -0,0 +1,151 @@
+// Package validate checks the quickstarts and help topics under docs/ the
+// way the console consumes them, and reports every problem it finds with
+// the file, line and column it comes from.
+package validate
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const contentBase = "./docs"
+
+// Exit codes: 1 when diagnostics reach the -fail-on threshold, 2 when the
+// validator itself could not run or write its report.
+const (
+	exitFailed = 1
+	exitError  = 2
+)
+
+// Options configures a validation run.
+type Options struct {
+	Links      *LinkPolicy         // Also check external URLs; nil skips them
+	Severities map[string]Severity // Severity of rules by name, overriding their default
+}
+
+// Content validates the help topics and quickstarts under base, lints them,
+// and checks the references between them.
+func Content(base string, schema *jsonschema.Schema, opts Options) *Report {
+	taxonomy := TagTaxonomy()
+	r := &Report{}
+	validateHelpTopics(r, base, taxonomy, opts.Severities)
+	validateQuickstarts(r, base, schema, taxonomy, opts.Severities)
+	r.add(validateReferences(base, opts.Links)...)
+	r.applySeverities(opts.Severities)
+	r.sort()
+	return r
+}
+
+// Command runs the validator with command line args, writes the report to
+// stdout or -output, and returns the process exit code.
+func Command(name string, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	checkLinks := flags.Bool("check-links", false, "also check the syntax and domain of external URLs (offline, nothing is fetched)")
+	linkDomains := flags.String("link-domains", strings.Join(DefaultLinkDomains, ","), "comma-separated domains allowed by -check-links; subdomains are allowed too")
+	format := flags.String("format", "text", "output format: "+strings.Join(formatNames(), ", "))
+	output := flags.String("output", "", "write the report to this file instead of stdout")
+	failOn := flags.String("fail-on", "error", "lowest severity that fails the run: error, warning or never")
+	rules := flags.String("rules", "", "comma-separated rule=severity overrides, such as raw-html=off,link=error; severity is error, warning or off")
+	listRules := flags.Bool("list-rules", false, "list the rules with their default severity and exit")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if *listRules {
+		writeRules(stdout)
+		return 0
+	}
+
+	fail := func(err error) int {
+		fmt.Fprintf(stderr, "%s: %s\n", name, err)
+		return exitError
+	}
+	write, ok := outputFormats[*format]
+	if !ok {
+		return fail(fmt.Errorf("unknown format %q; use one of %s", *format, strings.Join(formatNames(), ", ")))
+	}
+	switch *failOn {
+	case "error", "warning", "never":
+	default:
+		return fail(fmt.Errorf("unknown -fail-on %q; use error, warning or never", *failOn))
+	}
+	severities, err := ParseSeverities(*rules)
+	if err != nil {
+		return fail(err)
+	}
+	schema, err := LoadSchema(SchemaPath)
+	if err != nil {
+		return fail(err)
+	}
+
+	opts := Options{Severities: severities}
+	if *checkLinks {
+		opts.Links = &LinkPolicy{}
+		for _, domain := range strings.Split(*linkDomains, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				opts.Links.Domains = append(opts.Links.Domains, domain)
+			}
+		}
+	}
+
+	// Progress lines would break the machine-readable formats on stdout.
+	if *format == "text" {
+		fmt.Fprintln(stderr, "Validating help topics, quickstarts and references")
+	}
+	r := Content(contentBase, schema, opts)
+
+	if err := writeReport(*output, stdout, write, r); err != nil {
+		return fail(err)
+	}
+	if r.Failed(*failOn) {
+		return exitFailed
+	}
+	return 0
+}
+
+// writeReport writes r to the file at path, or to stdout when path is empty.
+func writeReport(path string, stdout io.Writer, write func(io.Writer, *Report) error, r *Report) error {
+	if path == "" {
+		return write(stdout, r)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeRules lists every rule with its default severity and description.
+func writeRules(w io.Writer) {
+	defaults := map[string]Severity{ruleLink: SeverityWarning}
+	for _, rule := range lintRules {
+		defaults[rule.Name] = rule.Severity
+	}
+	descriptions := ruleNames()
+	for _, name := range sortedRuleNames() {
+		severity := defaults[name]
+		if severity == "" {
+			severity = SeverityError
+		}
+		fmt.Fprintf(w, "%-24s %-8s %s\n", name, severity, descriptions[name])
+	}
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(outputFormats))
+	for name := range outputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,323 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// DefaultLinkDomains is the allow-list used by -check-links unless
+// -link-domains replaces it.
+var DefaultLinkDomains = []string{
+	"redhat.com",
+	"openshift.com",
+	"patternfly.org",
+	"github.com",
+	"github.io",
+	"quay.io",
+	"slack.com",
+	"docker.com",
+	"devfile.io",
+	"nvidia.com",
+	"surge.sh",
+	"uxd-hub.com",
+	"base64.guru",
+}
+
+var (
+	// Console links open a quickstart with ?quickstart=<name>.
+	quickstartRefPattern = regexp.MustCompile(`[?&]quickstart=([A-Za-z0-9._-]+)`)
+	urlPattern           = regexp.MustCompile(`https?://[^\s<>"'()\[\]{}]+`)
+)
+
+// LinkPolicy configures the opt-in external link check. It works offline:
+// URLs are parsed, never fetched.
+type LinkPolicy struct {
+	Domains []string // Allowed hosts; their subdomains are allowed too
+}
+
+// check returns what is wrong with raw, or "" when it is a well-formed
+// http(s) URL on an allowed domain. Paths starting with / are console links
+// and always pass.
+func (p *LinkPolicy) check(raw string) string {
+	if strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Sprintf("invalid URL %q: %s", raw, err.Error())
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Sprintf("URL %q must use http or https", raw)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Sprintf("URL %q has no host", raw)
+	}
+	for _, domain := range p.Domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("URL %q points at %s, which is not on the allow-list", raw, host)
+}
+
+// contentDocument is a metadata file and the content file it describes.
+type contentDocument struct {
+	metadataFile string
+	file         string
+	name         string
+	nameNode     *yamlv3.Node
+	metadata     *yamlv3.Node
+	content      *yamlv3.Node
+}
+
+// loadContentDocuments reads the metadata files matching pattern and their
+// content files. Files that cannot be read or parsed are skipped, since the
+// structure checks already report them.
+func loadContentDocuments(pattern string) []contentDocument {
+	metadataFiles, _ := filepath.Glob(pattern)
+
+	var documents []contentDocument
+	for _, metadataFile := range metadataFiles {
+		doc := contentDocument{metadataFile: metadataFile}
+		var metadata yamlv3.Node
+		if readYAMLNode(metadataFile, &metadata) != nil {
+			continue
+		}
+		key, nameNode := findNode(&metadata, []string{"name"})
+		if key == nil || nameNode.Value == "" {
+			continue
+		}
+		doc.name, doc.nameNode, doc.metadata = nameNode.Value, nameNode, &metadata
+		doc.file = contentFileName(metadataFile, doc.name)
+		doc.content = &yamlv3.Node{}
+		if readYAMLNode(doc.file, doc.content) != nil {
+			continue
+		}
+		documents = append(documents, doc)
+	}
+	return documents
+}
+
+func readYAMLNode(file string, node *yamlv3.Node) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return yamlv3.Unmarshal(content, node)
+}
+
+// validateReferences checks the references between the quickstarts and help
+// topics under base: names must be unique, and every nextQuickStart entry and
+// every ?quickstart= link in the text must name an existing quickstart.
+// Previous names of renamed quickstarts must not be in use; references to
+// them still resolve, so they are warnings. The replacement of a deprecated
+// item must exist. With a link policy, external URLs
+// are checked against it too and reported as warnings. Inline lint-disable
+// and lint-warn comments apply to these diagnostics as well.
+//
+// Prerequisites are free text, so only their ?quickstart= links are checked,
+// and help topics have no field that names another help topic.
+func validateReferences(base string, links *LinkPolicy) []Diagnostic {
+	quickstarts := loadContentDocuments(filepath.Join(base, "quickstarts", "*", "metadata.y*"))
+	helpTopics := loadContentDocuments(filepath.Join(base, "help-topics", "*", "metadata.y*"))
+
+	var result []Diagnostic
+	result = append(result, duplicateNames("quickstart", quickstarts)...)
+	result = append(result, duplicateNames("help topic group", helpTopics)...)
+
+	quickstartNames := map[string]bool{}
+	for _, q := range quickstarts {
+		quickstartNames[q.name] = true
+	}
+
+	renamedTo := map[string]string{}
+	for _, q := range quickstarts {
+		previous := lookupNode(q.metadata, []string{"previousNames"})
+		if previous == nil || previous.Kind != yamlv3.SequenceNode {
+			continue
+		}
+		for i, item := range previous.Content {
+			var problem string
+			switch other, claimed := renamedTo[item.Value]; {
+			case item.Value == q.name:
+				problem = fmt.Sprintf("previous name %q is the quickstart's own name", item.Value)
+			case quickstartNames[item.Value]:
+				problem = fmt.Sprintf("previous name %q is the name of another quickstart", item.Value)
+			case claimed:
+				problem = fmt.Sprintf("previous name %q is also listed by quickstart %q", item.Value, other)
+			default:
+				renamedTo[item.Value] = q.name
+				continue
+			}
+			result = append(result, newDiagnostic(ruleReference, q.metadataFile, item, []string{"previousNames", strconv.Itoa(i)}, problem))
+		}
+	}
+	// reference reports a reference to name, or returns nil when it resolves
+	// to a current quickstart.
+	reference := func(file string, node *yamlv3.Node, location []string, prefix, name string) *Diagnostic {
+		if quickstartNames[name] {
+			return nil
+		}
+		if current, ok := renamedTo[name]; ok {
+			d := newDiagnostic(ruleReference, file, node, location,
+				fmt.Sprintf("%squickstart %q was renamed to %q", prefix, name, current))
+			d.Severity = SeverityWarning
+			return &d
+		}
+		d := newDiagnostic(ruleReference, file, node, location, prefix+unknownMessage("quickstart", name, sortedKeys(quickstartNames)))
+		return &d
+	}
+
+	// Help topic names must be unique across groups, since they are looked
+	// up by name alone.
+	topicFiles := map[string]string{}
+	topicNames := map[string]bool{}
+	for _, h := range helpTopics {
+		_, topics := findNode(h.content, nil)
+		if topics.Kind != yamlv3.SequenceNode {
+			continue
+		}
+		for i, topic := range topics.Content {
+			_, nameNode := findNode(topic, []string{"name"})
+			if nameNode == topic {
+				continue
+			}
+			if first, ok := topicFiles[nameNode.Value]; ok {
+				result = append(result, newDiagnostic(ruleReference, h.file, nameNode, []string{strconv.Itoa(i), "name"},
+					fmt.Sprintf("help topic %q is also defined in %s", nameNode.Value, first)))
+				continue
+			}
+			topicFiles[nameNode.Value] = h.file
+			topicNames[nameNode.Value] = true
+		}
+	}
+
+	// The replacement of a deprecated quickstart is a quickstart, and that of
+	// a deprecated help topic group a help topic.
+	replacementLocation := []string{"deprecation", "replacement"}
+	for _, q := range quickstarts {
+		if node := lookupNode(q.metadata, replacementLocation); node != nil && node.Value != "" {
+			if d := reference(q.metadataFile, node, replacementLocation, "replacement ", node.Value); d != nil {
+				result = append(result, *d)
+			}
+		}
+	}
+	for _, h := range helpTopics {
+		if node := lookupNode(h.metadata, replacementLocation); node != nil && node.Value != "" {
+			if !topicNames[node.Value] {
+				result = append(result, newDiagnostic(ruleReference, h.metadataFile, node, replacementLocation,
+					unknownMessage("replacement help topic", node.Value, sortedKeys(topicNames))))
+			}
+		}
+	}
+
+	for _, q := range quickstarts {
+		_, next := findNode(q.content, []string{"spec", "nextQuickStart"})
+		if next.Kind == yamlv3.SequenceNode {
+			for i, item := range next.Content {
+				if d := reference(q.file, item, []string{"spec", "nextQuickStart", strconv.Itoa(i)}, "", item.Value); d != nil {
+					result = append(result, *d)
+				}
+			}
+		}
+		if links != nil {
+			if key, href := findNode(q.content, []string{"spec", "link", "href"}); key != nil && href.Kind == yamlv3.ScalarNode {
+				if problem := links.check(href.Value); problem != "" {
+					result = append(result, linkDiagnostic(q.file, href, []string{"spec", "link", "href"}, problem))
+				}
+			}
+		}
+	}
+
+	for _, doc := range append(quickstarts, helpTopics...) {
+		walkScalars(doc.content, nil, func(node *yamlv3.Node, location []string) {
+			for _, match := range quickstartRefPattern.FindAllStringSubmatch(node.Value, -1) {
+				if d := reference(doc.file, node, location, "link to ", match[1]); d != nil {
+					result = append(result, *d)
+				}
+			}
+			if links == nil {
+				return
+			}
+			for _, raw := range urlPattern.FindAllString(node.Value, -1) {
+				raw = strings.TrimRight(raw, ".,;:!?*")
+				if problem := links.check(raw); problem != "" {
+					result = append(result, linkDiagnostic(doc.file, node, location, problem))
+				}
+			}
+		})
+	}
+
+	roots := map[string]*yamlv3.Node{}
+	for _, doc := range append(quickstarts, helpTopics...) {
+		roots[doc.file], roots[doc.metadataFile] = doc.content, doc.metadata
+	}
+	result = applyInlineSeverities(result, roots)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].File != result[j].File {
+			return result[i].File < result[j].File
+		}
+		return result[i].Line < result[j].Line
+	})
+	return result
+}
+
+func linkDiagnostic(file string, node *yamlv3.Node, location []string, problem string) Diagnostic {
+	d := newDiagnostic(ruleLink, file, node, location, problem)
+	d.Severity = SeverityWarning
+	return d
+}
+
+func duplicateNames(what string, documents []contentDocument) []Diagnostic {
+	var result []Diagnostic
+	first := map[string]string{}
+	for _, doc := range documents {
+		if file, ok := first[doc.name]; ok {
+			result = append(result, newDiagnostic(ruleReference, doc.metadataFile, doc.nameNode, []string{"name"},
+				fmt.Sprintf("%s name %q is also used by %s", what, doc.name, file)))
+			continue
+		}
+		first[doc.name] = doc.metadataFile
+	}
+	return result
+}
+
+// walkScalars calls fn for every scalar value under node, with its location.
+// Mapping keys are not visited.
+func walkScalars(node *yamlv3.Node, location []string, fn func(*yamlv3.Node, []string)) {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		for _, child := range node.Content {
+			walkScalars(child, location, fn)
+		}
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkScalars(node.Content[i+1], append(append([]string{}, location...), node.Content[i].Value), fn)
+		}
+	case yamlv3.SequenceNode:
+		for i, child := range node.Content {
+			walkScalars(child, append(append([]string{}, location...), strconv.Itoa(i)), fn)
+		}
+	case yamlv3.ScalarNode:
+		fn(node, location)
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
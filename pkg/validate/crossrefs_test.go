@@ -0,0 +1,117 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeContentTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	base := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(base, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return base
+}
+
+func TestValidateReferences(t *testing.T) {
+	base := writeContentTree(t, map[string]string{
+		"quickstarts/first/metadata.yml": "kind: QuickStarts\nname: first\nstatus: deprecated\ndeprecation:\n  replacement: renamed\n",
+		"quickstarts/first/first.yml": `metadata:
+  name: first
+spec:
+  displayName: First
+  description: See the [second quick start](/learning-resources?quickstart=second).
+  link:
+    href: ftp://example.com/docs
+  nextQuickStart:
+    - second
+    - secnd
+    - renamed
+`,
+		"quickstarts/second/metadata.yml": "kind: QuickStarts\nname: second\npreviousNames:\n  - renamed\n  - first\n",
+		"quickstarts/second/second.yml": `metadata:
+  name: second
+spec:
+  displayName: Second
+  conclusion: |-
+    Continue with [a removed quick start](https://console.redhat.com/learning-resources?quickstart=removed).
+    Read [the docs](https://docs.redhat.com/en/documentation) and [a blog](https://blog.example.com/post).
+`,
+		"quickstarts/second-copy/metadata.yml": "kind: QuickStarts\nname: second\npreviousNames: [renamed]\n",
+		"quickstarts/second-copy/second.yaml":  "metadata:\n  name: second\nspec: {}\n",
+		"help-topics/group-a/metadata.yml":     "kind: HelpTopic\nname: group-a\n",
+		"help-topics/group-a/group-a.yml":      "- name: topic\n  title: Topic\n  content: Opens ?quickstart=first\n",
+		"help-topics/group-b/metadata.yml":     "kind: HelpTopic\nname: group-b\nstatus: deprecated\ndeprecation:\n  replacement: topc\n",
+		"help-topics/group-b/group-b.yml":      "- name: other\n  title: Other\n  content: x\n- name: topic\n  title: Topic\n  content: x\n",
+	})
+
+	messages := func(errs []Diagnostic) []string {
+		var result []string
+		for _, e := range errs {
+			rel, err := filepath.Rel(base, e.File)
+			require.NoError(t, err)
+			e.File = filepath.ToSlash(rel)
+			result = append(result, e.String())
+		}
+		return result
+	}
+
+	t.Run("resolves internal references", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`help-topics/group-b/group-b.yml:4:9: error: /1/name: help topic "topic" is also defined in ` + filepath.Join(base, "help-topics/group-a/group-a.yml") + " [reference]",
+			`help-topics/group-b/metadata.yml:5:16: error: /deprecation/replacement: unknown replacement help topic "topc"; did you mean "topic"? [reference]`,
+			`quickstarts/first/first.yml:10:7: error: /spec/nextQuickStart/1: unknown quickstart "secnd"; did you mean "second"? [reference]`,
+			`quickstarts/first/first.yml:11:7: warning: /spec/nextQuickStart/2: quickstart "renamed" was renamed to "second" [reference]`,
+			`quickstarts/first/metadata.yml:5:16: warning: /deprecation/replacement: replacement quickstart "renamed" was renamed to "second" [reference]`,
+			`quickstarts/second-copy/metadata.yml:2:7: error: /name: quickstart name "second" is also used by ` + filepath.Join(base, "quickstarts/second/metadata.yml") + " [reference]",
+			`quickstarts/second-copy/metadata.yml:3:17: error: /previousNames/0: previous name "renamed" is also listed by quickstart "second" [reference]`,
+			`quickstarts/second/metadata.yml:5:5: error: /previousNames/1: previous name "first" is the name of another quickstart [reference]`,
+			`quickstarts/second/second.yml:5:15: error: /spec/conclusion: link to unknown quickstart "removed" [reference]`,
+		}, messages(validateReferences(base, nil)))
+	})
+
+	t.Run("checks external links when asked", func(t *testing.T) {
+		errs := validateReferences(base, &LinkPolicy{Domains: []string{"redhat.com"}})
+		assert.Contains(t, messages(errs), `quickstarts/first/first.yml:7:11: warning: /spec/link/href: URL "ftp://example.com/docs" must use http or https [link]`)
+		assert.Contains(t, messages(errs), `quickstarts/second/second.yml:5:15: warning: /spec/conclusion: URL "https://blog.example.com/post" points at blog.example.com, which is not on the allow-list [link]`)
+		assert.Len(t, errs, 11)
+	})
+}
+
+func TestLinkPolicy(t *testing.T) {
+	policy := &LinkPolicy{Domains: []string{"redhat.com"}}
+	assert.Empty(t, policy.check("https://redhat.com"))
+	assert.Empty(t, policy.check("https://docs.redhat.com/en?x=1"))
+	assert.Empty(t, policy.check("/insights/dashboard"))
+	assert.Contains(t, policy.check("https://notredhat.com"), "not on the allow-list")
+	assert.Contains(t, policy.check("//redhat.com"), "must use http or https")
+	assert.Contains(t, policy.check("https://"), "has no host")
+	assert.Contains(t, policy.check("https://redhat.com/%zz"), "invalid URL")
+}
+
+func TestValidateReferencesInlineSeverities(t *testing.T) {
+	base := writeContentTree(t, map[string]string{
+		"quickstarts/first/metadata.yml": "kind: QuickStarts\nname: first\n",
+		"quickstarts/first/first.yml":    "metadata:\n  name: first\nspec:\n  nextQuickStart:\n    - missing # lint-warn reference\n    - other\n",
+		"help-topics/a/metadata.yml":     "kind: HelpTopic\nname: a\n",
+		"help-topics/a/a.yml":            "- name: topic\n  title: Topic\n  content: x\n",
+		"help-topics/b/metadata.yml":     "kind: HelpTopic\nname: b\n",
+		"help-topics/b/b.yml":            "- name: topic # lint-disable reference\n  title: Topic\n  content: x\n",
+	})
+
+	var got []string
+	for _, d := range validateReferences(base, nil) {
+		got = append(got, string(d.Severity)+" "+d.Path)
+	}
+	assert.Equal(t, []string{
+		"warning /spec/nextQuickStart/0",
+		"error /spec/nextQuickStart/1",
+	}, got)
+}
@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rules group diagnostics by the check that produced them.
+const (
+	ruleFile      = "file"
+	ruleStructure = "structure"
+	ruleSchema    = "schema"
+	ruleTag       = "tag"
+	ruleReference = "reference"
+	ruleLink      = "link"
+)
+
+var ruleDescriptions = map[string]string{
+	ruleFile:      "Content files must exist and be valid YAML",
+	ruleStructure: "Metadata and help topic files must have the required fields",
+	ruleSchema:    "Quickstarts must match the QuickStarts JSON Schema",
+	ruleTag:       "Tags must use known kinds and values",
+	ruleReference: "References between quickstarts and help topics must resolve, and names must be unique",
+	ruleLink:      "External URLs must be well formed and on the domain allow-list",
+}
+
+// Diagnostic is a content problem located in the YAML source it came from.
+// Line and Column are 0 when the problem has no position, such as a missing
+// file.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Path     string   `json:"path,omitempty"` // JSON pointer to the offending value
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	if d.Path != "" {
+		return fmt.Sprintf("%s: %s: %s: %s [%s]", location, d.Severity, d.Path, d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", location, d.Severity, d.Message, d.Rule)
+}
+
+func newDiagnostic(rule, file string, node *yamlv3.Node, location []string, msg string) Diagnostic {
+	d := Diagnostic{File: file, Path: "/" + strings.Join(location, "/"), Severity: SeverityError, Rule: rule, Message: msg}
+	if node != nil {
+		d.Line, d.Column = node.Line, node.Column
+	}
+	return d
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// fileDiagnostic reports a file that could not be read or parsed, at the line
+// the YAML parser complained about when there is one.
+func fileDiagnostic(file string, err error) Diagnostic {
+	d := Diagnostic{File: file, Severity: SeverityError, Rule: ruleFile, Message: err.Error()}
+	if match := yamlLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		d.Line, _ = strconv.Atoi(match[1])
+		d.Column = 1
+	}
+	return d
+}
+
+// structureDiagnostics converts an ozzo-validation error for the value at
+// location into one diagnostic per invalid field.
+func structureDiagnostics(file string, root *yamlv3.Node, location []string, err error) []Diagnostic {
+	var fieldErrors validation.Errors
+	if !errors.As(err, &fieldErrors) {
+		_, node := findNode(root, location)
+		return []Diagnostic{newDiagnostic(ruleStructure, file, node, location, err.Error())}
+	}
+	fields := make([]string, 0, len(fieldErrors))
+	for field := range fieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var result []Diagnostic
+	for _, field := range fields {
+		fieldLocation := append(append([]string{}, location...), field)
+		_, node := findNode(root, fieldLocation)
+		result = append(result, newDiagnostic(ruleStructure, file, node, fieldLocation, fieldErrors[field].Error()))
+	}
+	return result
+}
+
+// Report collects the diagnostics of a validation run and the files it
+// checked.
+type Report struct {
+	Files       []string
+	Diagnostics []Diagnostic
+}
+
+func (r *Report) checked(file string) {
+	r.Files = append(r.Files, file)
+}
+
+func (r *Report) add(diagnostics ...Diagnostic) {
+	r.Diagnostics = append(r.Diagnostics, diagnostics...)
+}
+
+func (r *Report) Count(s Severity) int {
+	n := 0
+	for _, d := range r.Diagnostics {
+		if d.Severity == s {
+			n++
+		}
+	}
+	return n
+}
+
+// applySeverities sets the severity of the diagnostics of every rule in
+// severities, and drops those of rules that are off.
+func (r *Report) applySeverities(severities map[string]Severity) {
+	if len(severities) == 0 {
+		return
+	}
+	kept := r.Diagnostics[:0]
+	for _, d := range r.Diagnostics {
+		if s, ok := severities[d.Rule]; ok {
+			if s == SeverityOff {
+				continue
+			}
+			d.Severity = s
+		}
+		kept = append(kept, d)
+	}
+	r.Diagnostics = kept
+}
+
+// sort orders diagnostics by file and position.
+func (r *Report) sort() {
+	sort.SliceStable(r.Diagnostics, func(i, j int) bool {
+		a, b := r.Diagnostics[i], r.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// Failed reports whether any diagnostic is at least as severe as threshold,
+// which is "error", "warning" or "never".
+func (r *Report) Failed(threshold string) bool {
+	switch threshold {
+	case "never":
+		return false
+	case "warning":
+		return len(r.Diagnostics) > 0
+	default:
+		return r.Count(SeverityError) > 0
+	}
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// readContentFile records file as checked and returns its content and YAML
+// node tree. When it cannot be read or parsed, a diagnostic is added to r
+// and ok is false.
+func readContentFile(r *Report, file string) (content []byte, root *yamlv3.Node, ok bool) {
+	r.checked(file)
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		r.add(fileDiagnostic(file, err))
+		return nil, nil, false
+	}
+	root = &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(content, root); err != nil {
+		r.add(fileDiagnostic(file, err))
+		return nil, nil, false
+	}
+	return content, root, true
+}
+
+// contentFileName returns the content file that a metadata file describes:
+// <name>.yml next to it, or <name>.yaml when there is no .yml file.
+func contentFileName(metadataFile, name string) string {
+	m := regexp.MustCompile("metadata.ya?ml$")
+	if fileName := m.ReplaceAllString(metadataFile, name+".yml"); fileExists(fileName) {
+		return fileName
+	}
+	return m.ReplaceAllString(metadataFile, name+".yaml")
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+func notMatch(r string, msg string) validation.RuleFunc {
+	return func(value interface{}) error {
+		s := value.(string)
+		matched, err := regexp.MatchString(r, s)
+		if err != nil {
+			return nil
+		}
+
+		if matched {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
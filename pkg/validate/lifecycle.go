@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// validateLifecycle checks the lifecycle fields of a metadata file the way
+// seeding reads them: a known status, dates that parse, an unpublishAt after
+// the publishAt, and a replacement only on deprecated items.
+func validateLifecycle(file string, root *yamlv3.Node) []Diagnostic {
+	var result []Diagnostic
+	status := models.StatusPublished
+	if node := lookupNode(root, []string{"status"}); node != nil {
+		status = node.Value
+		switch status {
+		case models.StatusDraft, models.StatusPublished, models.StatusDeprecated:
+		default:
+			result = append(result, newDiagnostic(ruleStructure, file, node, []string{"status"},
+				fmt.Sprintf("unknown status %q, expected draft, published or deprecated", status)))
+		}
+	}
+
+	dates := map[string]time.Time{}
+	for _, field := range []string{"publishAt", "unpublishAt"} {
+		node := lookupNode(root, []string{field})
+		if node == nil {
+			continue
+		}
+		parsed, ok := parseLifecycleTime(node.Value)
+		if !ok {
+			result = append(result, newDiagnostic(ruleStructure, file, node, []string{field},
+				fmt.Sprintf("%s %q is not a date or an RFC 3339 timestamp", field, node.Value)))
+			continue
+		}
+		dates[field] = parsed
+	}
+	publishAt, hasPublishAt := dates["publishAt"]
+	if unpublishAt, ok := dates["unpublishAt"]; ok && hasPublishAt && !unpublishAt.After(publishAt) {
+		location := []string{"unpublishAt"}
+		result = append(result, newDiagnostic(ruleStructure, file, lookupNode(root, location), location,
+			"unpublishAt must be after publishAt"))
+	}
+
+	location := []string{"deprecation", "replacement"}
+	if node := lookupNode(root, location); node != nil && status != models.StatusDeprecated {
+		result = append(result, newDiagnostic(ruleStructure, file, node, location,
+			fmt.Sprintf("replacement is only used when status is deprecated, not %q", status)))
+	}
+	return result
+}
+
+// parseLifecycleTime parses an RFC 3339 timestamp or a plain date.
+func parseLifecycleTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
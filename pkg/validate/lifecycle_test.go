@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestValidateLifecycle(t *testing.T) {
+	check := func(t *testing.T, content string) []string {
+		var root yamlv3.Node
+		require.NoError(t, yamlv3.Unmarshal([]byte(content), &root))
+		var result []string
+		for _, d := range validateLifecycle("metadata.yml", &root) {
+			result = append(result, d.String())
+		}
+		return result
+	}
+
+	t.Run("accepts lifecycle metadata", func(t *testing.T) {
+		assert.Empty(t, check(t, "kind: QuickStarts\nname: plain\n"))
+		assert.Empty(t, check(t, `kind: QuickStarts
+name: scheduled
+status: deprecated
+publishAt: 2026-01-01
+unpublishAt: "2026-06-01T12:00:00+02:00"
+deprecation:
+  replacement: other
+`))
+	})
+
+	t.Run("reports invalid lifecycle metadata", func(t *testing.T) {
+		assert.Equal(t, []string{
+			`metadata.yml:3:9: error: /status: unknown status "retired", expected draft, published or deprecated [structure]`,
+			`metadata.yml:5:14: error: /unpublishAt: unpublishAt must be after publishAt [structure]`,
+			`metadata.yml:7:16: error: /deprecation/replacement: replacement is only used when status is deprecated, not "retired" [structure]`,
+		}, check(t, `kind: QuickStarts
+name: invalid
+status: retired
+publishAt: 2026-02-01
+unpublishAt: 2026-01-01
+deprecation:
+  replacement: other
+`))
+		assert.Equal(t, []string{
+			`metadata.yml:3:12: error: /publishAt: publishAt "next week" is not a date or an RFC 3339 timestamp [structure]`,
+		}, check(t, "kind: HelpTopic\nname: invalid\npublishAt: next week\n"))
+	})
+}
@@ -0,0 +1,199 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// SeverityOff turns a lint rule off.
+const SeverityOff Severity = "off"
+
+// LintRule is a content check beyond structure, such as style, accessibility
+// or size. Rules are registered with RegisterLintRule and run on every
+// quickstart and help topic file.
+type LintRule struct {
+	Name        string
+	Description string
+	Severity    Severity // Default severity; Options.Severities overrides it
+	Check       func(item LintItem) []LintProblem
+}
+
+// LintItem is a content file handed to lint rules.
+type LintItem struct {
+	Kind string // "quickstart" or "helptopic"
+	File string
+	Root *yamlv3.Node
+}
+
+// LintProblem is a problem a lint rule found at Location in the item.
+type LintProblem struct {
+	Location []string
+	Message  string
+}
+
+var lintRules []LintRule
+
+// RegisterLintRule adds rule to the rules run on every content file.
+func RegisterLintRule(rule LintRule) {
+	lintRules = append(lintRules, rule)
+}
+
+// inlinePattern matches inline severity comments: "# lint-disable" turns off
+// every lint rule and "# lint-warn" reports every rule as a warning;
+// "# lint-disable rule-a, rule-b" applies to the listed ones only.
+var inlinePattern = regexp.MustCompile(`(?m)^#\s*lint-(disable|warn)\b[ \t]*(.*)$`)
+
+// lint runs the registered rules that severities does not turn off on item.
+// An inline comment above or beside a key applies to its value and
+// everything under it; at the top of the file, separated by an empty line,
+// to the whole file.
+func lint(item LintItem, severities map[string]Severity) []Diagnostic {
+	var result []Diagnostic
+	for _, rule := range lintRules {
+		if severities[rule.Name] == SeverityOff {
+			continue
+		}
+		for _, problem := range rule.Check(item) {
+			inline := inlineSeverity(item.Root, problem.Location, rule.Name)
+			if inline == SeverityOff {
+				continue
+			}
+			_, node := findNode(item.Root, problem.Location)
+			d := newDiagnostic(rule.Name, item.File, node, problem.Location, problem.Message)
+			d.Severity = rule.Severity
+			if inline != "" {
+				d.Severity = inline
+			}
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// applyInlineSeverities applies the inline comments of the files in roots to
+// diagnostics that were not produced by lint, such as broken references.
+func applyInlineSeverities(diagnostics []Diagnostic, roots map[string]*yamlv3.Node) []Diagnostic {
+	kept := diagnostics[:0]
+	for _, d := range diagnostics {
+		if root, ok := roots[d.File]; ok {
+			location := strings.Split(strings.TrimPrefix(d.Path, "/"), "/")
+			switch inline := inlineSeverity(root, location, d.Rule); inline {
+			case SeverityOff:
+				continue
+			case "":
+			default:
+				d.Severity = inline
+			}
+		}
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+// inlineSeverity returns the severity an inline comment on the document or on
+// a node along location gives rule, or "" when there is none. The comment
+// nearest to the value wins.
+func inlineSeverity(root *yamlv3.Node, location []string, rule string) Severity {
+	nodes := []*yamlv3.Node{root}
+	node := root
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	nodes = append(nodes, node)
+	for _, token := range location {
+		var next, key *yamlv3.Node
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					key, next = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			break
+		}
+		if key != nil {
+			nodes = append(nodes, key)
+		}
+		nodes = append(nodes, next)
+		node = next
+	}
+
+	for i := len(nodes) - 1; i >= 0; i-- {
+		for _, comment := range []string{nodes[i].LineComment, nodes[i].HeadComment} {
+			for _, match := range inlinePattern.FindAllStringSubmatch(comment, -1) {
+				severity := SeverityOff
+				if match[1] == "warn" {
+					severity = SeverityWarning
+				}
+				names := strings.FieldsFunc(match[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+				if len(names) == 0 || slices.Contains(names, rule) {
+					return severity
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// ParseSeverities parses a comma-separated list of rule=severity pairs, such
+// as "raw-html=off,link=error", checking rule names and severities.
+func ParseSeverities(value string) (map[string]Severity, error) {
+	severities := map[string]Severity{}
+	known := ruleNames()
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, severity, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q must be rule=severity", pair)
+		}
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		switch s := Severity(severity); s {
+		case SeverityError, SeverityWarning, SeverityOff:
+			severities[name] = s
+		default:
+			return nil, fmt.Errorf("unknown severity %q for %s; use error, warning or off", severity, name)
+		}
+	}
+	return severities, nil
+}
+
+// ruleNames returns the description of every rule, built in and lint.
+func ruleNames() map[string]string {
+	names := map[string]string{}
+	for name, description := range ruleDescriptions {
+		names[name] = description
+	}
+	for _, rule := range lintRules {
+		names[rule.Name] = rule.Description
+	}
+	return names
+}
+
+// sortedRuleNames returns the built-in rules in the order they run, then the
+// lint rules by name.
+func sortedRuleNames() []string {
+	names := []string{ruleFile, ruleStructure, ruleSchema, ruleTag, ruleReference, ruleLink}
+	var lintNames []string
+	for _, rule := range lintRules {
+		lintNames = append(lintNames, rule.Name)
+	}
+	sort.Strings(lintNames)
+	return append(names, lintNames...)
+}
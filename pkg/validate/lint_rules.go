@@ -0,0 +1,268 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	// maxCardDescription is the longest description that fits the three
+	// lines of a learning resources card.
+	maxCardDescription = 115
+	// maxTaskDescription is the longest task description that still reads
+	// comfortably in the quick start side panel.
+	maxTaskDescription = 2000
+)
+
+// markdownFields are the locations of the Markdown text of each kind of
+// item; "*" matches every entry of a list.
+var markdownFields = map[string][][]string{
+	"quickstart": {
+		{"spec", "description"},
+		{"spec", "introduction"},
+		{"spec", "conclusion"},
+		{"spec", "prerequisites", "*"},
+		{"spec", "tasks", "*", "description"},
+		{"spec", "tasks", "*", "review", "instructions"},
+		{"spec", "tasks", "*", "review", "failedTaskHelp"},
+		{"spec", "tasks", "*", "summary", "success"},
+		{"spec", "tasks", "*", "summary", "failed"},
+	},
+	"helptopic": {
+		{"*", "content"},
+	},
+}
+
+var (
+	codeFencePattern = regexp.MustCompile("(?s)```.*?(```|$)")
+	codeSpanPattern  = regexp.MustCompile("`[^`\n]*`")
+	headingPattern   = regexp.MustCompile(`(?m)^ {0,3}(#{1,6})[ \t]+\S`)
+	imagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(`)
+	htmlImagePattern = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltPattern   = regexp.MustCompile(`(?i)\balt\s*=\s*("[^"]*\S[^"]*"|'[^']*\S[^']*')`)
+	htmlTagPattern   = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9-]*)(\s[^<>]*)?/?>`)
+)
+
+func init() {
+	RegisterLintRule(LintRule{
+		Name:        "image-alt",
+		Description: "Images need alternative text for screen readers",
+		Severity:    SeverityError,
+		Check:       eachMarkdownField(checkImageAlt),
+	})
+	RegisterLintRule(LintRule{
+		Name:        "heading-order",
+		Description: "Markdown headings must not skip levels",
+		Severity:    SeverityWarning,
+		Check:       eachMarkdownField(checkHeadingOrder),
+	})
+	RegisterLintRule(LintRule{
+		Name:        "raw-html",
+		Description: "Markdown text should not use raw HTML, which the console may not render",
+		Severity:    SeverityWarning,
+		Check:       eachMarkdownField(checkRawHTML),
+	})
+	RegisterLintRule(LintRule{
+		Name:        "task-description-length",
+		Description: fmt.Sprintf("Task descriptions should be at most %d characters; split long tasks", maxTaskDescription),
+		Severity:    SeverityWarning,
+		Check:       checkTaskDescriptionLength,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "description-length",
+		Description: fmt.Sprintf("Descriptions should be at most %d characters to fit the card", maxCardDescription),
+		Severity:    SeverityWarning,
+		Check:       checkDescriptionLength,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "quickstart-duration",
+		Description: "Items of type Quick start need durationMinutes",
+		Severity:    SeverityError,
+		Check:       checkQuickstartDuration,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "help-topic-empty-tags",
+		Description: "Help topic tags should list tags or be left out",
+		Severity:    SeverityWarning,
+		Check:       checkHelpTopicEmptyTags,
+	})
+}
+
+// eachMarkdownField returns a rule check that runs check on the text of
+// every Markdown field of an item.
+func eachMarkdownField(check func(text string) []string) func(LintItem) []LintProblem {
+	return func(item LintItem) []LintProblem {
+		var problems []LintProblem
+		for _, pattern := range markdownFields[item.Kind] {
+			matchLocations(item.Root, pattern, nil, func(node *yamlv3.Node, location []string) {
+				if node.Kind != yamlv3.ScalarNode {
+					return
+				}
+				for _, msg := range check(stripCode(node.Value)) {
+					problems = append(problems, LintProblem{Location: location, Message: msg})
+				}
+			})
+		}
+		return problems
+	}
+}
+
+// matchLocations calls fn for every node under node that matches pattern,
+// with its full location.
+func matchLocations(node *yamlv3.Node, pattern, location []string, fn func(*yamlv3.Node, []string)) {
+	if node.Kind == yamlv3.DocumentNode {
+		if len(node.Content) > 0 {
+			matchLocations(node.Content[0], pattern, location, fn)
+		}
+		return
+	}
+	if len(pattern) == 0 {
+		fn(node, location)
+		return
+	}
+	switch {
+	case pattern[0] == "*" && node.Kind == yamlv3.SequenceNode:
+		for i, child := range node.Content {
+			matchLocations(child, pattern[1:], append(append([]string{}, location...), strconv.Itoa(i)), fn)
+		}
+	case node.Kind == yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == pattern[0] {
+				matchLocations(node.Content[i+1], pattern[1:], append(append([]string{}, location...), pattern[0]), fn)
+			}
+		}
+	}
+}
+
+// lookupNode returns the node at location, or nil when there is none.
+func lookupNode(root *yamlv3.Node, location []string) *yamlv3.Node {
+	var found *yamlv3.Node
+	matchLocations(root, location, nil, func(node *yamlv3.Node, _ []string) {
+		found = node
+	})
+	return found
+}
+
+// stripCode blanks out fenced code blocks and code spans, where headings,
+// images and HTML are only text.
+func stripCode(text string) string {
+	blank := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, s)
+	}
+	text = codeFencePattern.ReplaceAllStringFunc(text, blank)
+	return codeSpanPattern.ReplaceAllStringFunc(text, blank)
+}
+
+func checkImageAlt(text string) []string {
+	var problems []string
+	for _, match := range imagePattern.FindAllStringSubmatch(text, -1) {
+		if strings.TrimSpace(match[1]) == "" {
+			problems = append(problems, "image has no alt text; describe it between the brackets of ![...](...)")
+		}
+	}
+	for _, tag := range htmlImagePattern.FindAllString(text, -1) {
+		if !htmlAltPattern.MatchString(tag) {
+			problems = append(problems, fmt.Sprintf("image %s has no alt attribute", tag))
+		}
+	}
+	return problems
+}
+
+func checkHeadingOrder(text string) []string {
+	var problems []string
+	previous := 0
+	for _, match := range headingPattern.FindAllStringSubmatch(text, -1) {
+		level := len(match[1])
+		if previous > 0 && level > previous+1 {
+			problems = append(problems, fmt.Sprintf("heading level %d follows level %d; use level %d", level, previous, previous+1))
+		}
+		previous = level
+	}
+	return problems
+}
+
+func checkRawHTML(text string) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, match := range htmlTagPattern.FindAllStringSubmatch(text, -1) {
+		tag := strings.ToLower(match[1])
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, "<"+tag+">")
+		}
+	}
+	if strings.Contains(text, "<!--") {
+		tags = append(tags, "<!-- -->")
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("raw HTML %s; use Markdown instead", strings.Join(tags, ", "))}
+}
+
+func checkTaskDescriptionLength(item LintItem) []LintProblem {
+	var problems []LintProblem
+	if item.Kind != "quickstart" {
+		return nil
+	}
+	matchLocations(item.Root, []string{"spec", "tasks", "*", "description"}, nil, func(node *yamlv3.Node, location []string) {
+		if n := utf8.RuneCountInString(node.Value); node.Kind == yamlv3.ScalarNode && n > maxTaskDescription {
+			problems = append(problems, LintProblem{Location: location,
+				Message: fmt.Sprintf("task description is %d characters, more than %d; split the task", n, maxTaskDescription)})
+		}
+	})
+	return problems
+}
+
+func checkDescriptionLength(item LintItem) []LintProblem {
+	if item.Kind != "quickstart" {
+		return nil
+	}
+	location := []string{"spec", "description"}
+	node := lookupNode(item.Root, location)
+	if node == nil || node.Kind != yamlv3.ScalarNode {
+		return nil
+	}
+	if n := utf8.RuneCountInString(strings.TrimSpace(node.Value)); n > maxCardDescription {
+		return []LintProblem{{Location: location,
+			Message: fmt.Sprintf("description is %d characters, more than the %d that fit on a card", n, maxCardDescription)}}
+	}
+	return nil
+}
+
+func checkQuickstartDuration(item LintItem) []LintProblem {
+	if item.Kind != "quickstart" {
+		return nil
+	}
+	typeText := lookupNode(item.Root, []string{"spec", "type", "text"})
+	if typeText == nil || !strings.EqualFold(typeText.Value, "Quick start") {
+		return nil
+	}
+	if lookupNode(item.Root, []string{"spec", "durationMinutes"}) == nil {
+		return []LintProblem{{Location: []string{"spec"}, Message: "a Quick start needs durationMinutes"}}
+	}
+	return nil
+}
+
+func checkHelpTopicEmptyTags(item LintItem) []LintProblem {
+	if item.Kind != "helptopic" {
+		return nil
+	}
+	var problems []LintProblem
+	matchLocations(item.Root, []string{"*", "tags"}, nil, func(node *yamlv3.Node, location []string) {
+		if (node.Kind == yamlv3.ScalarNode && node.Tag == "!!null") || (node.Kind == yamlv3.SequenceNode && len(node.Content) == 0) {
+			problems = append(problems, LintProblem{Location: location, Message: "tags is empty; add tags or remove it"})
+		}
+	})
+	return problems
+}
@@ -0,0 +1,173 @@
+package validate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func lintContent(t *testing.T, kind, content string, severities map[string]Severity) []string {
+	t.Helper()
+	var root yamlv3.Node
+	require.NoError(t, yamlv3.Unmarshal([]byte(content), &root))
+	var result []string
+	for _, d := range lint(LintItem{Kind: kind, File: "item.yml", Root: &root}, severities) {
+		result = append(result, d.String())
+	}
+	return result
+}
+
+func TestLintRules(t *testing.T) {
+	t.Run("checks quickstart Markdown, size and duration", func(t *testing.T) {
+		content := `metadata:
+  name: lint
+spec:
+  displayName: Lint
+  type:
+    text: Quick start
+  description: ` + strings.Repeat("x", maxCardDescription+1) + `
+  introduction: |-
+    # Title
+    ### Skipped
+    ![](images/screen.png) and <img src="a.png">
+  tasks:
+    - title: Task
+      description: |-
+        Use <b>bold</b> and <br/>, but ` + "`<code>`" + ` is fine.
+        ![Console](images/console.png) <img src="b.png" alt="B">
+    - title: Long
+      description: ` + strings.Repeat("y", maxTaskDescription+1) + `
+`
+		assert.Equal(t, []string{
+			"item.yml:8:17: error: /spec/introduction: image has no alt text; describe it between the brackets of ![...](...) [image-alt]",
+			`item.yml:8:17: error: /spec/introduction: image <img src="a.png"> has no alt attribute [image-alt]`,
+			"item.yml:8:17: warning: /spec/introduction: heading level 3 follows level 1; use level 2 [heading-order]",
+			"item.yml:8:17: warning: /spec/introduction: raw HTML <img>; use Markdown instead [raw-html]",
+			"item.yml:14:20: warning: /spec/tasks/0/description: raw HTML <b>, <br>, <img>; use Markdown instead [raw-html]",
+			"item.yml:18:20: warning: /spec/tasks/1/description: task description is 2001 characters, more than 2000; split the task [task-description-length]",
+			"item.yml:7:16: warning: /spec/description: description is 116 characters, more than the 115 that fit on a card [description-length]",
+			"item.yml:4:3: error: /spec: a Quick start needs durationMinutes [quickstart-duration]",
+		}, lintContent(t, "quickstart", content, nil))
+	})
+
+	t.Run("accepts a quickstart with duration and plain Markdown", func(t *testing.T) {
+		content := `spec:
+  type:
+    text: Quick start
+  durationMinutes: 5
+  description: Short.
+  introduction: |-
+    # Title
+    ## Section
+    ![A chart](chart.png)
+    ` + "```\n    <div>code</div>\n    ```" + `
+`
+		assert.Empty(t, lintContent(t, "quickstart", content, nil))
+	})
+
+	t.Run("reports empty help topic tags", func(t *testing.T) {
+		content := `- name: empty
+  tags:
+  content: Text
+- name: list
+  tags: []
+  content: Text
+- name: tagged
+  tags:
+    - kind: bundle
+      value: insights
+  content: Text
+`
+		assert.Equal(t, []string{
+			"item.yml:2:8: warning: /0/tags: tags is empty; add tags or remove it [help-topic-empty-tags]",
+			"item.yml:5:9: warning: /1/tags: tags is empty; add tags or remove it [help-topic-empty-tags]",
+		}, lintContent(t, "helptopic", content, nil))
+	})
+
+	t.Run("skips rules that are off", func(t *testing.T) {
+		content := "- name: empty\n  tags: []\n  content: <b>Text</b>\n"
+		assert.Equal(t, []string{
+			"item.yml:2:9: warning: /0/tags: tags is empty; add tags or remove it [help-topic-empty-tags]",
+		}, lintContent(t, "helptopic", content, map[string]Severity{"raw-html": SeverityOff}))
+	})
+}
+
+func TestLintDisableComments(t *testing.T) {
+	t.Run("on a key", func(t *testing.T) {
+		content := `spec:
+  # lint-disable raw-html
+  introduction: <b>Intro</b> ![](a.png)
+  conclusion: <b>Done</b> # lint-disable
+  prerequisites:
+    - <b>Access</b>
+`
+		assert.Equal(t, []string{
+			"item.yml:3:17: error: /spec/introduction: image has no alt text; describe it between the brackets of ![...](...) [image-alt]",
+			"item.yml:6:7: warning: /spec/prerequisites/0: raw HTML <b>; use Markdown instead [raw-html]",
+		}, lintContent(t, "quickstart", content, nil))
+	})
+
+	t.Run("on the file", func(t *testing.T) {
+		content := `# lint-disable raw-html, image-alt
+
+spec:
+  introduction: <b>Intro</b> ![](a.png)
+  description: ` + strings.Repeat("x", maxCardDescription+1) + `
+`
+		assert.Equal(t, []string{
+			"item.yml:5:16: warning: /spec/description: description is 116 characters, more than the 115 that fit on a card [description-length]",
+		}, lintContent(t, "quickstart", content, nil))
+	})
+
+	t.Run("as warnings", func(t *testing.T) {
+		content := `# lint-warn image-alt
+
+spec:
+  introduction: See ![](a.png)
+  # lint-disable image-alt
+  conclusion: See ![](b.png)
+`
+		assert.Equal(t, []string{
+			"item.yml:4:17: warning: /spec/introduction: image has no alt text; describe it between the brackets of ![...](...) [image-alt]",
+		}, lintContent(t, "quickstart", content, nil))
+	})
+}
+
+func TestParseSeverities(t *testing.T) {
+	severities, err := ParseSeverities("raw-html=off, link=error,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Severity{"raw-html": SeverityOff, "link": SeverityError}, severities)
+
+	_, err = ParseSeverities("raw-htm=off")
+	assert.EqualError(t, err, `unknown rule "raw-htm"`)
+	_, err = ParseSeverities("raw-html=fatal")
+	assert.EqualError(t, err, `unknown severity "fatal" for raw-html; use error, warning or off`)
+	_, err = ParseSeverities("raw-html")
+	assert.EqualError(t, err, `"raw-html" must be rule=severity`)
+}
+
+func TestApplySeverities(t *testing.T) {
+	r := &Report{Diagnostics: []Diagnostic{
+		{File: "a.yml", Severity: SeverityWarning, Rule: ruleLink},
+		{File: "a.yml", Severity: SeverityWarning, Rule: "raw-html"},
+		{File: "a.yml", Severity: SeverityError, Rule: ruleSchema},
+	}}
+	r.applySeverities(map[string]Severity{ruleLink: SeverityError, "raw-html": SeverityOff})
+	assert.Equal(t, []Diagnostic{
+		{File: "a.yml", Severity: SeverityError, Rule: ruleLink},
+		{File: "a.yml", Severity: SeverityError, Rule: ruleSchema},
+	}, r.Diagnostics)
+}
+
+func TestWriteRules(t *testing.T) {
+	var buf bytes.Buffer
+	writeRules(&buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, len(sortedRuleNames()))
+	assert.Contains(t, buf.String(), "image-alt")
+	assert.Contains(t, buf.String(), "raw-html")
+}
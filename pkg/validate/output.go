@@ -0,0 +1,217 @@
+package validate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var outputFormats = map[string]func(io.Writer, *Report) error{
+	"text":  writeText,
+	"json":  writeJSON,
+	"junit": writeJUnit,
+	"sarif": writeSARIF,
+}
+
+func writeText(w io.Writer, r *Report) error {
+	for _, d := range r.Diagnostics {
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d files checked: %d errors, %d warnings\n", len(r.Files), r.Count(SeverityError), r.Count(SeverityWarning))
+	return err
+}
+
+func writeJSON(w io.Writer, r *Report) error {
+	diagnostics := r.Diagnostics
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Files       int          `json:"files"`
+		Errors      int          `json:"errors"`
+		Warnings    int          `json:"warnings"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}{len(r.Files), r.Count(SeverityError), r.Count(SeverityWarning), diagnostics})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes one test case per checked file. A file with errors fails
+// with every error in the failure text; warnings go to its system-out.
+func writeJUnit(w io.Writer, r *Report) error {
+	byFile := map[string][]Diagnostic{}
+	for _, d := range r.Diagnostics {
+		byFile[d.File] = append(byFile[d.File], d)
+	}
+	files := append([]string{}, r.Files...)
+	for file := range byFile {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	suite := junitSuite{Name: "content"}
+	for _, file := range files {
+		tc := junitCase{Name: file, ClassName: "content"}
+		var errs, warnings []string
+		for _, d := range byFile[file] {
+			if d.Severity == SeverityError {
+				errs = append(errs, d.String())
+			} else {
+				warnings = append(warnings, d.String())
+			}
+		}
+		if len(errs) > 0 {
+			tc.Failure = &junitFailure{Message: fmt.Sprintf("%d errors", len(errs)), Type: string(SeverityError), Text: strings.Join(errs, "\n")}
+			suite.Failures++
+		}
+		tc.SystemOut = strings.Join(warnings, "\n")
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Name: "quickstarts-validate", Tests: suite.Tests, Failures: suite.Failures, Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// writeSARIF writes a SARIF 2.1.0 log, which code scanning uses to annotate
+// the offending lines of a pull request. File URIs are relative to the
+// repository root.
+func writeSARIF(w io.Writer, r *Report) error {
+	var rules []sarifRule
+	descriptions := ruleNames()
+	for _, id := range sortedRuleNames() {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: descriptions[id]}})
+	}
+	results := []sarifResult{}
+	for _, d := range r.Diagnostics {
+		message := d.Message
+		if d.Path != "" {
+			message = d.Path + ": " + message
+		}
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(filepath.Clean(d.File))}}
+		if d.Line > 0 {
+			location.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "quickstarts-validate",
+				InformationURI: "https://github.com/RedHatInsights/quickstarts",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
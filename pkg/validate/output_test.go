@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCollectsAllDiagnostics(t *testing.T) {
+	base := writeContentTree(t, map[string]string{
+		"quickstarts/missing/metadata.yml": "kind: QuickStarts\nname: missing\n",
+		"quickstarts/broken/metadata.yml":  "kind: QuickStarts\nname: broken\n",
+		"quickstarts/broken/broken.yml":    "metadata:\n  name: broken\nspec:\n  displayName: [unclosed\n",
+		"quickstarts/renamed/metadata.yml": "kind: Quickstarts\nname: renamed\ntags:\n  - kind: bundel\n    value: rhel\n",
+		"quickstarts/renamed/renamed.yml": `metadata:
+  name: old-name
+spec:
+  displayName: Renamed
+  description: Renamed
+  type:
+    text: Quick start
+`,
+		"help-topics/group/metadata.yml": "kind: HelpTopic\nname: group\n",
+		"help-topics/group/group.yml":    "- name: topic one\n  title: Topic\n  content: Content\n",
+	})
+	schema, err := LoadSchema("../../spec/quickstart.schema.json")
+	require.NoError(t, err)
+
+	r := &Report{}
+	taxonomy := TagTaxonomy()
+	validateHelpTopics(r, base, taxonomy, nil)
+	validateQuickstarts(r, base, schema, taxonomy, nil)
+	r.sort()
+
+	var got []string
+	for _, d := range r.Diagnostics {
+		relative, err := filepath.Rel(base, d.File)
+		require.NoError(t, err)
+		d.File = relative
+		// File errors carry OS and parser text that varies between platforms.
+		if d.Rule == ruleFile {
+			d.Message = "-"
+		}
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		`help-topics/group/group.yml:1:9: error: /0/name: name can't include whitespaces [structure]`,
+		`quickstarts/broken/broken.yml:3:1: error: - [file]`,
+		`quickstarts/missing/missing.yaml: error: - [file]`,
+		`quickstarts/renamed/metadata.yml:1:7: error: /kind: must be a valid value [structure]`,
+		`quickstarts/renamed/metadata.yml:4:11: error: /tags/0/kind: unknown tag kind "bundel"; did you mean "bundle"? [tag]`,
+		`quickstarts/renamed/renamed.yml:2:9: error: /metadata/name: name "old-name" must match "renamed" in ` + filepath.Join(base, "quickstarts/renamed/metadata.yml") + ` [structure]`,
+		`quickstarts/renamed/renamed.yml:4:3: error: /spec: a Quick start needs durationMinutes [quickstart-duration]`,
+		`quickstarts/renamed/renamed.yml:7:5: error: /spec/type: missing property 'color' [schema]`,
+	}, got)
+	assert.Len(t, r.Files, 8)
+}
+
+func testReport() *Report {
+	return &Report{
+		Files: []string{"docs/quickstarts/a/a.yml", "docs/quickstarts/b/b.yml"},
+		Diagnostics: []Diagnostic{
+			{File: "docs/quickstarts/a/a.yml", Line: 3, Column: 5, Path: "/spec/type", Severity: SeverityError, Rule: ruleSchema, Message: "missing property 'text'"},
+			{File: "docs/quickstarts/a/a.yml", Line: 9, Column: 7, Path: "/spec/link/href", Severity: SeverityWarning, Rule: ruleLink, Message: "URL \"https://example.com\" points at example.com, which is not on the allow-list"},
+			{File: "docs/quickstarts/c/c.yml", Severity: SeverityError, Rule: ruleFile, Message: "no such file or directory"},
+		},
+	}
+}
+
+func TestReportFailed(t *testing.T) {
+	r := testReport()
+	assert.True(t, r.Failed("error"))
+	assert.True(t, r.Failed("warning"))
+	assert.False(t, r.Failed("never"))
+
+	warningsOnly := &Report{Diagnostics: r.Diagnostics[1:2]}
+	assert.False(t, warningsOnly.Failed("error"))
+	assert.True(t, warningsOnly.Failed("warning"))
+	assert.False(t, (&Report{}).Failed("warning"))
+}
+
+func TestWriteText(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeText(&out, testReport()))
+	assert.Equal(t, `docs/quickstarts/a/a.yml:3:5: error: /spec/type: missing property 'text' [schema]
+docs/quickstarts/a/a.yml:9:7: warning: /spec/link/href: URL "https://example.com" points at example.com, which is not on the allow-list [link]
+docs/quickstarts/c/c.yml: error: no such file or directory [file]
+2 files checked: 2 errors, 1 warnings
+`, out.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJSON(&out, testReport()))
+
+	var decoded struct {
+		Files       int
+		Errors      int
+		Warnings    int
+		Diagnostics []Diagnostic
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, 2, decoded.Files)
+	assert.Equal(t, 2, decoded.Errors)
+	assert.Equal(t, 1, decoded.Warnings)
+	assert.Equal(t, testReport().Diagnostics, decoded.Diagnostics)
+
+	out.Reset()
+	require.NoError(t, writeJSON(&out, &Report{}))
+	assert.Contains(t, out.String(), `"diagnostics": []`)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJUnit(&out, testReport()))
+
+	var decoded junitTestSuites
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, 3, decoded.Tests)
+	assert.Equal(t, 2, decoded.Failures)
+	require.Len(t, decoded.Suites, 1)
+
+	cases := decoded.Suites[0].Cases
+	require.Len(t, cases, 3)
+	assert.Equal(t, "docs/quickstarts/a/a.yml", cases[0].Name)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "1 errors", cases[0].Failure.Message)
+	assert.Contains(t, cases[0].Failure.Text, "missing property 'text'")
+	assert.Contains(t, cases[0].SystemOut, "not on the allow-list")
+	assert.Nil(t, cases[1].Failure)
+	assert.Equal(t, "docs/quickstarts/c/c.yml", cases[2].Name)
+	require.NotNil(t, cases[2].Failure)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	r := testReport()
+	r.Diagnostics[0].File = "./docs/quickstarts/a/a.yml"
+	var out bytes.Buffer
+	require.NoError(t, writeSARIF(&out, r))
+
+	var decoded sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "2.1.0", decoded.Version)
+	require.Len(t, decoded.Runs, 1)
+	run := decoded.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, len(sortedRuleNames()))
+	require.Len(t, run.Results, 3)
+
+	first := run.Results[0]
+	assert.Equal(t, ruleSchema, first.RuleID)
+	assert.Equal(t, "error", first.Level)
+	assert.Equal(t, "/spec/type: missing property 'text'", first.Message.Text)
+	assert.Equal(t, "docs/quickstarts/a/a.yml", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 5}, first.Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, "warning", run.Results[1].Level)
+	assert.Nil(t, run.Results[2].Locations[0].PhysicalLocation.Region)
+	assert.False(t, strings.Contains(out.String(), `"region": null`))
+}
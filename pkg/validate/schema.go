@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const SchemaPath = "./spec/quickstart.schema.json"
+
+var schemaPrinter = message.NewPrinter(language.English)
+
+func LoadSchema(path string) (*jsonschema.Schema, error) {
+	return jsonschema.NewCompiler().Compile(path)
+}
+
+// validateSchema validates the YAML document in content against schema and
+// returns every violation with the line and column of the value, or of the
+// key for unknown properties. Errors are sorted by position.
+func validateSchema(schema *jsonschema.Schema, file string, content []byte) ([]Diagnostic, error) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonContent))
+	if err != nil {
+		return nil, err
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if err := schema.Validate(instance); !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var result []Diagnostic
+	for _, leaf := range leafErrors(validationErr) {
+		if additional, ok := leaf.ErrorKind.(*kind.AdditionalProperties); ok {
+			for _, property := range additional.Properties {
+				location := append(append([]string{}, leaf.InstanceLocation...), property)
+				key, _ := findNode(&root, location)
+				result = append(result, newDiagnostic(ruleSchema, file, key, location, fmt.Sprintf("unknown property %q", property)))
+			}
+			continue
+		}
+		_, value := findNode(&root, leaf.InstanceLocation)
+		result = append(result, newDiagnostic(ruleSchema, file, value, leaf.InstanceLocation, leaf.ErrorKind.LocalizedString(schemaPrinter)))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].Column < result[j].Column
+	})
+	return result, nil
+}
+
+// leafErrors flattens a validation error tree into the errors that caused it.
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// findNode walks location from the document root and returns the key and
+// value nodes it ends at. The key is nil for the root and for array items;
+// both are the deepest existing node when location does not exist.
+func findNode(root *yamlv3.Node, location []string) (*yamlv3.Node, *yamlv3.Node) {
+	var key *yamlv3.Node
+	node := root
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, token := range location {
+		if node.Kind == yamlv3.AliasNode {
+			node = node.Alias
+		}
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					key, node = node.Content[i], node.Content[i+1]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return key, node
+			}
+		case yamlv3.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return key, node
+			}
+			key, node = nil, node.Content[i]
+		default:
+			return key, node
+		}
+	}
+	return key, node
+}
@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchema(t *testing.T) {
+	schema, err := LoadSchema("../../spec/quickstart.schema.json")
+	require.NoError(t, err)
+
+	t.Run("accepts a valid quickstart", func(t *testing.T) {
+		content := `apiVersion: console.openshift.io/v1
+kind: QuickStarts
+metadata:
+  name: valid
+spec:
+  displayName: Valid
+  description: A valid quickstart
+  durationMinutes: 5
+  icon: ~
+  type:
+    text: Quick start
+    color: green
+  tasks:
+    - title: First task
+      description: Do something
+      review:
+        instructions: Did it work?
+        failedTaskHelp: Try again.
+      summary:
+        success: Done
+        failed: Not done
+`
+		errs, err := validateSchema(schema, "valid.yml", []byte(content))
+		require.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("locates every error", func(t *testing.T) {
+		content := `kind: QuickStarts
+metadata:
+  name: broken
+extra: true
+spec:
+  displayName: Broken
+  description: A broken quickstart
+  durationMinutes: 10 (active)
+  type:
+    text: Quick start
+    color: pink
+  tasks:
+    - description: No title
+    - title: Bad review
+      review:
+        instructions: Did it work?
+        failedTaskHelp:
+          - not a string
+    - title: Bad summary
+      summary: Done
+`
+		errs, err := validateSchema(schema, "broken.yml", []byte(content))
+		require.NoError(t, err)
+
+		type location struct {
+			Line, Column int
+			Path         string
+		}
+		var got []location
+		for _, e := range errs {
+			assert.Equal(t, "broken.yml", e.File)
+			assert.NotEmpty(t, e.Message)
+			got = append(got, location{e.Line, e.Column, e.Path})
+		}
+		assert.Equal(t, []location{
+			{4, 1, "/extra"},
+			{8, 20, "/spec/durationMinutes"},
+			{11, 12, "/spec/type/color"},
+			{13, 7, "/spec/tasks/0"},
+			{18, 11, "/spec/tasks/1/review/failedTaskHelp"},
+			{20, 16, "/spec/tasks/2/summary"},
+		}, got)
+		assert.Equal(t, `unknown property "extra"`, errs[0].Message)
+		assert.Equal(t, "broken.yml:4:1: error: /extra: unknown property \"extra\" [schema]", errs[0].String())
+	})
+
+	t.Run("reports YAML syntax errors", func(t *testing.T) {
+		_, err := validateSchema(schema, "invalid.yml", []byte("spec: [unclosed\n"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// unfilteredTagValues are values shipped content uses that the console does
+// not offer as filters. They are valid tags, but no filter selects them.
+var unfilteredTagValues = map[models.TagType][]string{
+	models.ProductFamilies: {"security"},
+	models.UseCase:         {"application"},
+}
+
+// TagTaxonomy returns the allowed values of each tag kind that has a closed
+// set: bundles, and the kinds offered as frontend filters plus
+// unfilteredTagValues. Other kinds, such as application, accept any value.
+func TagTaxonomy() map[models.TagType][]string {
+	taxonomy := map[models.TagType][]string{models.BundleTag: models.Bundles}
+	for _, category := range models.FrontendFilters.Categories {
+		for _, group := range category.CategoryData {
+			for _, item := range group.Data {
+				taxonomy[category.CategoryID] = append(taxonomy[category.CategoryID], item.Id)
+			}
+		}
+	}
+	for kind, values := range unfilteredTagValues {
+		taxonomy[kind] = append(taxonomy[kind], values...)
+	}
+	return taxonomy
+}
+
+// validateTags checks the tags of a metadata file: every tag needs a kind and
+// a value, every kind must be a known TagType, and every value must, for kinds
+// in the taxonomy, be one of the known values. Unknown kinds and values come
+// with the closest known one when it looks like a typo. YAML drops the
+// surrounding whitespace of plain scalars, so only quoted values are checked
+// for it.
+func validateTags(taxonomy map[models.TagType][]string, file string, content []byte) ([]Diagnostic, error) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	_, tags := findNode(&root, []string{"tags"})
+	if tags == nil || tags.Kind != yamlv3.SequenceNode {
+		return nil, nil
+	}
+
+	var kinds []string
+	for _, kind := range models.TagType("").GetAllTags() {
+		kinds = append(kinds, string(kind))
+	}
+
+	var result []Diagnostic
+	for i, tag := range tags.Content {
+		location := []string{"tags", strconv.Itoa(i)}
+		_, kindNode := findNode(tag, []string{"kind"})
+		_, valueNode := findNode(tag, []string{"value"})
+		// findNode stops at the tag itself when a field is missing.
+		if kindNode == tag || valueNode == tag || kindNode.Value == "" || valueNode.Value == "" {
+			result = append(result, newDiagnostic(ruleTag, file, tag, location, "tag needs a kind and a value"))
+			continue
+		}
+
+		kind := models.TagType(kindNode.Value)
+		if !kind.IsValidTag() {
+			result = append(result, newDiagnostic(ruleTag, file, kindNode, append(location, "kind"),
+				unknownMessage("tag kind", kindNode.Value, kinds)))
+			continue
+		}
+
+		value := valueNode.Value
+		quoted := valueNode.Style&(yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle) != 0
+		if quoted && strings.TrimSpace(value) != value {
+			result = append(result, newDiagnostic(ruleTag, file, valueNode, append(location, "value"),
+				fmt.Sprintf("%s tag %q has leading or trailing whitespace", kind, value)))
+			value = strings.TrimSpace(value)
+		}
+		if known, ok := taxonomy[kind]; ok && !slices.Contains(known, value) {
+			result = append(result, newDiagnostic(ruleTag, file, valueNode, append(location, "value"),
+				unknownMessage(string(kind)+" tag", value, known)))
+		}
+	}
+	return result, nil
+}
+
+func unknownMessage(what, value string, known []string) string {
+	msg := fmt.Sprintf("unknown %s %q", what, value)
+	if suggestion := closestMatch(value, known); suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+	return msg
+}
+
+// closestMatch returns the entry of known nearest to value, ignoring case,
+// or "" when none is close enough to be a likely typo.
+func closestMatch(value string, known []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range known {
+		d := levenshtein(strings.ToLower(value), strings.ToLower(candidate))
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if bestDistance < 0 || bestDistance > max(2, len(best)/3) {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
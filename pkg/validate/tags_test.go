@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTags(t *testing.T) {
+	taxonomy := TagTaxonomy()
+
+	t.Run("builds the taxonomy from bundles and frontend filters", func(t *testing.T) {
+		assert.Equal(t, models.Bundles, taxonomy[models.BundleTag])
+		assert.Contains(t, taxonomy[models.UseCase], "spend-management")
+		assert.Contains(t, taxonomy[models.ContentType], "learningPath")
+		assert.Contains(t, taxonomy[models.ProductFamilies], "rhel")
+		assert.NotContains(t, taxonomy, models.ApplicationTag, "application values are not constrained")
+		assert.Contains(t, taxonomy[models.UseCase], "application", "used by shipped content without a filter")
+		assert.Contains(t, taxonomy[models.ProductFamilies], "security", "used by shipped content without a filter")
+	})
+
+	t.Run("accepts known tags", func(t *testing.T) {
+		content := `kind: QuickStarts
+name: valid
+tags:
+  - kind: bundle
+    value: insights
+  - kind: content
+    value: documentation   
+  - kind: application
+    value: any-application
+`
+		errs, err := validateTags(taxonomy, "metadata.yml", []byte(content))
+		require.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("reports unknown kinds and values with suggestions", func(t *testing.T) {
+		content := `kind: QuickStarts
+name: typos
+tags:
+  - kind: bundel
+    value: insights
+  - kind: bundle
+    value: Ansible
+  - kind: use-case
+    value: automaton
+  - kind: use-case
+    value: gardening
+  - kind: content
+    value: " documentation"
+  - kind: content
+`
+		errs, err := validateTags(taxonomy, "metadata.yml", []byte(content))
+		require.NoError(t, err)
+
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.String())
+		}
+		assert.Equal(t, []string{
+			`metadata.yml:4:11: error: /tags/0/kind: unknown tag kind "bundel"; did you mean "bundle"? [tag]`,
+			`metadata.yml:7:12: error: /tags/1/value: unknown bundle tag "Ansible"; did you mean "ansible"? [tag]`,
+			`metadata.yml:9:12: error: /tags/2/value: unknown use-case tag "automaton"; did you mean "automation"? [tag]`,
+			`metadata.yml:11:12: error: /tags/3/value: unknown use-case tag "gardening" [tag]`,
+			`metadata.yml:13:12: error: /tags/4/value: content tag " documentation" has leading or trailing whitespace [tag]`,
+			`metadata.yml:14:5: error: /tags/5: tag needs a kind and a value [tag]`,
+		}, got)
+	})
+}
+
+func TestClosestMatch(t *testing.T) {
+	known := []string{"clusters", "containers", "deploy"}
+	assert.Equal(t, "clusters", closestMatch("cluster", known))
+	assert.Equal(t, "deploy", closestMatch("DEPLOY", known))
+	assert.Equal(t, "", closestMatch("billing", known))
+	assert.Equal(t, "", closestMatch("anything", nil))
+}
@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/ghodss/yaml"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+type QuickstartMetadata struct {
+	Name                  string `json:"name,omitempty"`
+	ExternalDocumentation bool   `json:"externalDocumentation,omitempty"`
+}
+
+type TypeField struct {
+	Text  string `json:"text,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+type LinkType struct {
+	Href string `json:"href,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+type SpecStruct struct {
+	Version     float32   `json:"version,omitempty"`
+	Type        TypeField `json:"type,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Icon        string    `json:"icon,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Link        LinkType  `json:"link,omitempty"`
+}
+
+type QuickStarts struct {
+	ApiVersion string             `json:"apiVersion,omitempty"`
+	Kind       string             `json:"kind,omitempty"`
+	Metadata   QuickstartMetadata `json:"metadata,omitempty"`
+	Spec       SpecStruct         `json:"spec,omitempty"`
+}
+
+// validateQuickstarts checks every quickstart under base against the
+// QuickStarts JSON Schema, its metadata tags against the tag taxonomy, and
+// that its name matches its metadata file, and lints it, adding what is
+// wrong to r.
+func validateQuickstarts(r *Report, base string, schema *jsonschema.Schema, taxonomy map[models.TagType][]string, severities map[string]Severity) {
+	metadataFiles, _ := filepath.Glob(filepath.Join(base, "quickstarts", "*", "metadata.y*"))
+
+	for _, filePath := range metadataFiles {
+		yamlfile, root, ok := readContentFile(r, filePath)
+		if !ok {
+			continue
+		}
+		var metadata TopicMetadata
+		if err := yaml.Unmarshal(yamlfile, &metadata); err != nil {
+			r.add(fileDiagnostic(filePath, err))
+			continue
+		}
+		err := validation.ValidateStruct(&metadata,
+			validation.Field(&metadata.Kind, validation.Required, validation.In("QuickStarts")),
+			validation.Field(&metadata.Name, validation.Required),
+		)
+		if err != nil {
+			r.add(structureDiagnostics(filePath, root, nil, err)...)
+		}
+		tagErrors, err := validateTags(taxonomy, filePath, yamlfile)
+		if err != nil {
+			r.add(fileDiagnostic(filePath, err))
+		}
+		r.add(tagErrors...)
+		r.add(validateLifecycle(filePath, root)...)
+		if metadata.Name == "" {
+			continue
+		}
+
+		quickstartsFileName := contentFileName(filePath, metadata.Name)
+		yamlfile, root, ok = readContentFile(r, quickstartsFileName)
+		if !ok {
+			continue
+		}
+		errs, err := validateSchema(schema, quickstartsFileName, yamlfile)
+		if err != nil {
+			r.add(fileDiagnostic(quickstartsFileName, err))
+			continue
+		}
+		r.add(errs...)
+		r.add(lint(LintItem{Kind: "quickstart", File: quickstartsFileName, Root: root}, severities)...)
+
+		var content QuickStarts
+		if err := yaml.Unmarshal(yamlfile, &content); err != nil {
+			// The schema check has already reported values of the wrong type.
+			continue
+		}
+		if content.Metadata.Name != metadata.Name {
+			location := []string{"metadata", "name"}
+			_, node := findNode(root, location)
+			r.add(newDiagnostic(ruleStructure, quickstartsFileName, node, location,
+				fmt.Sprintf("name %q must match %q in %s", content.Metadata.Name, metadata.Name, filePath)))
+		}
+	}
+}
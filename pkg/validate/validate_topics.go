@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/RedHatInsights/quickstarts/pkg/models"
+	"github.com/ghodss/yaml"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type TopicTag struct {
+	Kind  string `json:"kind,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type TopicMetadata struct {
+	Kind string     `json:"kind,omitempty"`
+	Name string     `json:"name,omitempty"`
+	Tags []TopicTag `json:"tags,omitempty"`
+}
+
+type TopicContent struct {
+	Name    string   `json:"name,omitempty"`
+	Content string   `json:"content,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// validateHelpTopics checks and lints the metadata and topics of every help
+// topic group under base and adds what is wrong to r.
+func validateHelpTopics(r *Report, base string, taxonomy map[models.TagType][]string, severities map[string]Severity) {
+	metadataFiles, _ := filepath.Glob(filepath.Join(base, "help-topics", "*", "metadata.y*"))
+
+	for _, filePath := range metadataFiles {
+		yamlfile, root, ok := readContentFile(r, filePath)
+		if !ok {
+			continue
+		}
+		var metadata TopicMetadata
+		if err := yaml.Unmarshal(yamlfile, &metadata); err != nil {
+			r.add(fileDiagnostic(filePath, err))
+			continue
+		}
+		err := validation.ValidateStruct(&metadata,
+			validation.Field(&metadata.Kind, validation.Required, validation.In("HelpTopic")),
+			validation.Field(&metadata.Name, validation.Required, validation.By(notMatch(`\s`, "name can't include whitespaces"))),
+			validation.Field(&metadata.Tags, validation.Each(validation.Required)),
+		)
+		if err != nil {
+			r.add(structureDiagnostics(filePath, root, nil, err)...)
+		}
+
+		tagErrors, err := validateTags(taxonomy, filePath, yamlfile)
+		if err != nil {
+			r.add(fileDiagnostic(filePath, err))
+		}
+		r.add(tagErrors...)
+		r.add(validateLifecycle(filePath, root)...)
+		if metadata.Name == "" {
+			continue
+		}
+
+		// validate topic file existance
+		topicFileName := contentFileName(filePath, metadata.Name)
+		yamlfile, root, ok = readContentFile(r, topicFileName)
+		if !ok {
+			continue
+		}
+		var content []TopicContent
+		if err := yaml.Unmarshal(yamlfile, &content); err != nil {
+			r.add(fileDiagnostic(topicFileName, err))
+			continue
+		}
+		r.add(lint(LintItem{Kind: "helptopic", File: topicFileName, Root: root}, severities)...)
+
+		for i, c := range content {
+			err = validation.ValidateStruct(&c,
+				validation.Field(&c.Name, validation.Required, validation.By(notMatch(`\s`, "name can't include whitespaces"))),
+				validation.Field(&c.Content, validation.Required),
+				validation.Field(&c.Title, validation.Required),
+				validation.Field(&c.Tags, validation.Each(validation.Required)),
+			)
+			if err != nil {
+				r.add(structureDiagnostics(topicFileName, root, []string{strconv.Itoa(i)}, err)...)
+			}
+		}
+	}
+}